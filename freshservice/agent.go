@@ -1,12 +1,9 @@
 package freshservice
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
 )
 
 const agentURL = "/api/v2/agents"
@@ -15,13 +12,26 @@ const agentURL = "/api/v2/agents"
 // the agent endpoints of the Freshservice API
 type AgentService interface {
 	List(context.Context, QueryFilter) ([]AgentDetails, string, error)
+	ListWithResponse(context.Context, QueryFilter) ([]AgentDetails, *Response, error)
+	ListAll(context.Context, QueryFilter) ([]AgentDetails, error)
+	Iter(context.Context, QueryFilter) *AgentIterator
+	Prefetch(context.Context, QueryFilter, *PrefetchOptions) *AgentPrefetcher
 	Create(context.Context, *AgentDetails) (*AgentDetails, error)
+	CreateWithResponse(context.Context, *AgentDetails) (*AgentDetails, *Response, error)
 	Get(context.Context, int) (*AgentDetails, error)
+	GetWithResponse(context.Context, int) (*AgentDetails, *Response, error)
 	Update(context.Context, int, *AgentDetails) (*AgentDetails, error)
+	UpdateWithResponse(context.Context, int, *AgentDetails) (*AgentDetails, *Response, error)
+	UpdateFields(context.Context, int, *AgentUpdate) (*AgentDetails, error)
+	UpdateFieldsWithResponse(context.Context, int, *AgentUpdate) (*AgentDetails, *Response, error)
 	Delete(context.Context, int) error
+	DeleteWithResponse(context.Context, int) (*Response, error)
 	Deactivate(context.Context, int) (*AgentDetails, error)
+	DeactivateWithResponse(context.Context, int) (*AgentDetails, *Response, error)
 	Reactivate(context.Context, int) (*AgentDetails, error)
+	ReactivateWithResponse(context.Context, int) (*AgentDetails, *Response, error)
 	ConvertToRequester(context.Context, int) (*AgentDetails, error)
+	ConvertToRequesterWithResponse(context.Context, int) (*AgentDetails, *Response, error)
 }
 
 // AgentServiceClient facilitates requests with the AgentService methods
@@ -31,187 +41,287 @@ type AgentServiceClient struct {
 
 // List all freshservice agents
 func (as *AgentServiceClient) List(ctx context.Context, filter QueryFilter) ([]AgentDetails, string, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   as.client.Domain,
-		Path:   agentURL,
+	list, resp, err := as.ListWithResponse(ctx, filter)
+	if err != nil {
+		return nil, "", err
 	}
 
-	if filter != nil {
-		url.RawQuery = filter.QueryString()
-	}
+	return list, HasNextPage(resp.Response), nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+// ListWithResponse is the same as List but also returns the Freshservice API response
+func (as *AgentServiceClient) ListWithResponse(ctx context.Context, filter QueryFilter) ([]AgentDetails, *Response, error) {
+	req, err := as.client.newRequest(ctx, http.MethodGet, agentURL, filter, nil)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	res := &Agents{}
 	resp, err := as.client.makeRequest(req, res)
 	if err != nil {
-		return nil, "", err
+		return nil, resp, err
 	}
 
-	return res.List, HasNextPage(resp), nil
+	return res.List, resp, nil
 }
 
-// Get a specific Freshservice agent
-func (as *AgentServiceClient) Get(ctx context.Context, id int) (*AgentDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   as.client.Domain,
-		Path:   fmt.Sprintf("%s/%d", agentURL, id),
+// Iter returns an iterator over every agent matching the filter,
+// following the pagination links returned by Freshservice
+func (as *AgentServiceClient) Iter(ctx context.Context, filter QueryFilter) *AgentIterator {
+	it := &AgentIterator{}
+	it.pager = newPager(ctx, filter, func(ctx context.Context, f QueryFilter) (*Response, error) {
+		list, resp, err := as.ListWithResponse(ctx, f)
+		it.page = list
+		return resp, err
+	})
+	return it
+}
+
+// ListAll returns every agent matching the filter across all pages
+func (as *AgentServiceClient) ListAll(ctx context.Context, filter QueryFilter) ([]AgentDetails, error) {
+	var all []AgentDetails
+	it := as.Iter(ctx, filter)
+	for it.Next() {
+		all = append(all, it.Value())
 	}
+	return all, it.Err()
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
-	if err != nil {
-		return nil, err
+// AgentIterator iterates over Freshservice agents page by page
+type AgentIterator struct {
+	pager
+	page []AgentDetails
+	cur  AgentDetails
+}
+
+// Next advances the iterator to the next agent. It returns
+// false when there are none left or an error occurred.
+func (it *AgentIterator) Next() bool {
+	for len(it.page) == 0 {
+		if !it.fetchNext() {
+			return false
+		}
 	}
 
-	res := &Agent{}
-	if _, err := as.client.makeRequest(req, res); err != nil {
-		return nil, err
+	if !it.take() {
+		return false
 	}
 
-	return &res.Details, nil
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
 }
 
-// Create a new Freshserrvice agent
-func (as *AgentServiceClient) Create(ctx context.Context, ad *AgentDetails) (*AgentDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   as.client.Domain,
-		Path:   agentURL,
+// Value returns the current agent
+func (it *AgentIterator) Value() AgentDetails {
+	return it.cur
+}
+
+// Prefetch requests the pages of agents matching the filter concurrently
+// and delivers them in page order through the Pages channel
+func (as *AgentServiceClient) Prefetch(ctx context.Context, filter QueryFilter, opts *PrefetchOptions) *AgentPrefetcher {
+	p := &AgentPrefetcher{pages: make(chan AgentPage)}
+	fetch := func(ctx context.Context, f QueryFilter) (interface{}, *Response, error) {
+		return as.ListWithResponse(ctx, f)
+	}
+	emit := func(ctx context.Context, page int, items interface{}) bool {
+		select {
+		case p.pages <- AgentPage{Page: page, Agents: items.([]AgentDetails)}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
 	}
+	go p.run(ctx, filter, opts, fetch, emit, func() { close(p.pages) })
+	return p
+}
+
+// AgentPage is a single page of agents delivered by a AgentPrefetcher
+type AgentPage struct {
+	Page   int
+	Agents []AgentDetails
+}
+
+// AgentPrefetcher fetches pages of agents concurrently
+type AgentPrefetcher struct {
+	prefetcher
+	pages chan AgentPage
+}
+
+// Pages returns the channel the pages are delivered on in page order. It
+// is closed once every page has been delivered or prefetching stopped,
+// after which Err reports why. Cancel the context to stop early.
+func (p *AgentPrefetcher) Pages() <-chan AgentPage {
+	return p.pages
+}
+
+// Get a specific Freshservice agent
+func (as *AgentServiceClient) Get(ctx context.Context, id int) (*AgentDetails, error) {
+	details, _, err := as.GetWithResponse(ctx, id)
+	return details, err
+}
 
-	agentContent, err := json.Marshal(ad)
+// GetWithResponse is the same as Get but also returns the Freshservice API response
+func (as *AgentServiceClient) GetWithResponse(ctx context.Context, id int) (*AgentDetails, *Response, error) {
+	req, err := as.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%d", agentURL, id), nil, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	res := &Agent{}
+	resp, err := as.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	body := bytes.NewReader(agentContent)
+	return &res.Details, resp, nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url.String(), body)
+// Create a new Freshserrvice agent
+func (as *AgentServiceClient) Create(ctx context.Context, ad *AgentDetails) (*AgentDetails, error) {
+	details, _, err := as.CreateWithResponse(ctx, ad)
+	return details, err
+}
+
+// CreateWithResponse is the same as Create but also returns the Freshservice API response
+func (as *AgentServiceClient) CreateWithResponse(ctx context.Context, ad *AgentDetails) (*AgentDetails, *Response, error) {
+	req, err := as.client.newRequest(ctx, http.MethodPost, agentURL, nil, ad)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &Agent{}
-	if _, err := as.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := as.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return &res.Details, nil
+	return &res.Details, resp, nil
 }
 
-// Update a Freshservice agent
+// Update a Freshservice agent. Every field is sent, use UpdateFields
+// to change only some of them.
 func (as *AgentServiceClient) Update(ctx context.Context, id int, ad *AgentDetails) (*AgentDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   as.client.Domain,
-		Path:   fmt.Sprintf("%s/%d", agentURL, id),
+	details, _, err := as.UpdateWithResponse(ctx, id, ad)
+	return details, err
+}
+
+// UpdateWithResponse is the same as Update but also returns the Freshservice API response
+func (as *AgentServiceClient) UpdateWithResponse(ctx context.Context, id int, ad *AgentDetails) (*AgentDetails, *Response, error) {
+	req, err := as.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%d", agentURL, id), nil, ad)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	agentContent, err := json.Marshal(ad)
+	res := &Agent{}
+	resp, err := as.client.makeRequest(req, res)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	body := bytes.NewReader(agentContent)
+	return &res.Details, resp, nil
+}
+
+// UpdateFields sends only the fields set on the update, leaving the rest
+// of the agent unchanged, unlike Update which sends every field
+func (as *AgentServiceClient) UpdateFields(ctx context.Context, id int, u *AgentUpdate) (*AgentDetails, error) {
+	details, _, err := as.UpdateFieldsWithResponse(ctx, id, u)
+	return details, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url.String(), body)
+// UpdateFieldsWithResponse is the same as UpdateFields but also returns the Freshservice API response
+func (as *AgentServiceClient) UpdateFieldsWithResponse(ctx context.Context, id int, u *AgentUpdate) (*AgentDetails, *Response, error) {
+	req, err := as.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%d", agentURL, id), nil, u)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &Agent{}
-	if _, err := as.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := as.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return &res.Details, nil
-
+	return &res.Details, resp, nil
 }
 
 // Delete a Freshservice agent
 func (as *AgentServiceClient) Delete(ctx context.Context, id int) error {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   as.client.Domain,
-		Path:   fmt.Sprintf("%s/%d/forget", agentURL, id),
-	}
+	_, err := as.DeleteWithResponse(ctx, id)
+	return err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url.String(), nil)
+// DeleteWithResponse is the same as Delete but also returns the Freshservice API response
+func (as *AgentServiceClient) DeleteWithResponse(ctx context.Context, id int) (*Response, error) {
+	req, err := as.client.newRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%d/forget", agentURL, id), nil, nil)
 	if err != nil {
-		return err
-	}
-
-	if _, err := as.client.makeRequest(req, nil); err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return as.client.makeRequest(req, nil)
 }
 
 // Deactivate a Frehservice agent (does not delete)
 func (as *AgentServiceClient) Deactivate(ctx context.Context, id int) (*AgentDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   as.client.Domain,
-		Path:   fmt.Sprintf("%s/%d", agentURL, id),
-	}
+	details, _, err := as.DeactivateWithResponse(ctx, id)
+	return details, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url.String(), nil)
+// DeactivateWithResponse is the same as Deactivate but also returns the Freshservice API response
+func (as *AgentServiceClient) DeactivateWithResponse(ctx context.Context, id int) (*AgentDetails, *Response, error) {
+	req, err := as.client.newRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", agentURL, id), nil, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &Agent{}
-	if _, err := as.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := as.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return &res.Details, nil
+	return &res.Details, resp, nil
 }
 
 // Reactivate a Freshserrvice agent
 func (as *AgentServiceClient) Reactivate(ctx context.Context, id int) (*AgentDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   as.client.Domain,
-		Path:   fmt.Sprintf("%s/%d", agentURL, id),
-	}
+	details, _, err := as.ReactivateWithResponse(ctx, id)
+	return details, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url.String(), nil)
+// ReactivateWithResponse is the same as Reactivate but also returns the Freshservice API response
+func (as *AgentServiceClient) ReactivateWithResponse(ctx context.Context, id int) (*AgentDetails, *Response, error) {
+	req, err := as.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%d", agentURL, id), nil, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &Agent{}
-	if _, err := as.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := as.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return &res.Details, nil
+	return &res.Details, resp, nil
 }
 
 // ConvertToRequester will convert a Freshservice agent to a requester
 func (as *AgentServiceClient) ConvertToRequester(ctx context.Context, id int) (*AgentDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   as.client.Domain,
-		Path:   fmt.Sprintf("%s/%d/convert_to_requester", agentURL, id),
-	}
+	details, _, err := as.ConvertToRequesterWithResponse(ctx, id)
+	return details, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url.String(), nil)
+// ConvertToRequesterWithResponse is the same as ConvertToRequester but also returns the Freshservice API response
+func (as *AgentServiceClient) ConvertToRequesterWithResponse(ctx context.Context, id int) (*AgentDetails, *Response, error) {
+	req, err := as.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%d/convert_to_requester", agentURL, id), nil, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &Agent{}
-	if _, err := as.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := as.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return &res.Details, nil
+	return &res.Details, resp, nil
 }
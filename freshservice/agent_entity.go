@@ -2,6 +2,7 @@ package freshservice
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -48,6 +49,38 @@ type AgentDetails struct {
 	HasLoggedIn bool `json:"has_logged_in"`
 }
 
+// AgentUpdate holds the changes made to an agent with UpdateFields.
+// Only the fields that are set are sent, list the JSON names of fields
+// to clear, such as "reporting_manager_id", in Null.
+type AgentUpdate struct {
+	FirstName             *string      `json:"first_name,omitempty"`
+	LastName              *string      `json:"last_name,omitempty"`
+	Occasional            *bool        `json:"occasional,omitempty"`
+	JobTitle              *string      `json:"job_title,omitempty"`
+	Email                 *string      `json:"email,omitempty"`
+	WorkPhoneNumber       *string      `json:"work_phone_number,omitempty"`
+	MobilePhoneNumber     *string      `json:"mobile_phone_number,omitempty"`
+	ReportingManagerID    *int         `json:"reporting_manager_id,omitempty"`
+	Address               *string      `json:"address,omitempty"`
+	TimeZone              *string      `json:"time_zone,omitempty"`
+	TimeFormat            *string      `json:"time_format,omitempty"`
+	Language              *string      `json:"language,omitempty"`
+	LocationID            *int         `json:"location_id,omitempty"`
+	BackgroundInformation *string      `json:"background_information,omitempty"`
+	ScoreboardLevelID     *int         `json:"scoreboard_level_id,omitempty"`
+	MemberOf              []int        `json:"member_of,omitempty"`
+	ObserverOf            []int        `json:"observer_of,omitempty"`
+	Roles                 []AgentRole  `json:"roles,omitempty"`
+	CustomFields          CustomFields `json:"custom_fields,omitempty"`
+	Null                  []string     `json:"-"`
+}
+
+// MarshalJSON encodes the fields that are set and those cleared with Null
+func (u *AgentUpdate) MarshalJSON() ([]byte, error) {
+	type update AgentUpdate
+	return marshalUpdate((*update)(u), u.Null)
+}
+
 // AgentRole represents a Freshservice role that can be assigned to an agent
 type AgentRole struct {
 	RoleID          int    `json:"role_id"`
@@ -75,8 +108,13 @@ func (ar *AgentRole) Validate() error {
 	return nil
 }
 
-// AgentListFilter holds the filters available when listing Freservice agents
+// AgentListFilter holds the filters available when listing Freservice agents,
+// every filter set is applied together
 type AgentListFilter struct {
+	// Page selects the page and page size to return
+	Page *PageCursor
+	// Deprecated: PageQuery is the raw query returned by HasNextPage, only
+	// its page parameters are used. Use Page instead.
 	PageQuery   string
 	Email       *string
 	MobilePhone *int
@@ -88,24 +126,28 @@ type AgentListFilter struct {
 
 // QueryString allows the available filter items to meet the QueryFilter interface
 func (af *AgentListFilter) QueryString() string {
-	var qs []string
-	if af.PageQuery != "" {
-		qs = append(qs, af.PageQuery)
-	}
+	v := pageValues(af.Page, af.PageQuery)
 
-	switch {
-	case af.Email != nil:
-		qs = append(qs, fmt.Sprintf("email=%s", *af.Email))
-	case af.MobilePhone != nil:
-		qs = append(qs, fmt.Sprintf("mobile_phone_number=%d", *af.MobilePhone))
-	case af.WorkPhone != nil:
-		qs = append(qs, fmt.Sprintf("work_phone_number=%d", *af.WorkPhone))
-	case af.Active:
-		qs = append(qs, fmt.Sprintf("active=%v", af.Active))
-	case af.Fulltime:
-		qs = append(qs, "state=fulltime")
-	case af.Occasional:
-		qs = append(qs, "state=occasional")
+	if af.Email != nil {
+		v.Set("email", *af.Email)
 	}
-	return strings.Join(qs, "&")
+	if af.MobilePhone != nil {
+		v.Set("mobile_phone_number", strconv.Itoa(*af.MobilePhone))
+	}
+	if af.WorkPhone != nil {
+		v.Set("work_phone_number", strconv.Itoa(*af.WorkPhone))
+	}
+	if af.Active {
+		v.Set("active", "true")
+	}
+	// agents are either full time or occasional, asking for both is the same as neither
+	if af.Fulltime != af.Occasional {
+		if af.Fulltime {
+			v.Set("state", "fulltime")
+		} else {
+			v.Set("state", "occasional")
+		}
+	}
+
+	return v.Encode()
 }
@@ -1,12 +1,9 @@
 package freshservice
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
 )
 
 const announcementURL = "/api/v2/announcements"
@@ -15,10 +12,17 @@ const announcementURL = "/api/v2/announcements"
 // the announcement endpoints of the Freshservice API
 type AnnouncementService interface {
 	List(context.Context, QueryFilter) ([]AnnouncementDetails, error)
+	ListWithResponse(context.Context, QueryFilter) ([]AnnouncementDetails, *Response, error)
+	ListAll(context.Context, QueryFilter) ([]AnnouncementDetails, error)
+	Iter(context.Context, QueryFilter) *AnnouncementIterator
 	Get(context.Context, int) (*AnnouncementDetails, error)
+	GetWithResponse(context.Context, int) (*AnnouncementDetails, *Response, error)
 	Create(context.Context, *AnnouncementDetails) (*AnnouncementDetails, error)
+	CreateWithResponse(context.Context, *AnnouncementDetails) (*AnnouncementDetails, *Response, error)
 	Update(context.Context, int, *AnnouncementDetails) (*AnnouncementDetails, error)
+	UpdateWithResponse(context.Context, int, *AnnouncementDetails) (*AnnouncementDetails, *Response, error)
 	Delete(context.Context, int) error
+	DeleteWithResponse(context.Context, int) (*Response, error)
 }
 
 // AnnouncementServiceClient facilitates requests with the AnnouncementService methods
@@ -28,120 +32,155 @@ type AnnouncementServiceClient struct {
 
 // List announcements in Freshservice
 func (a *AnnouncementServiceClient) List(ctx context.Context, filter QueryFilter) ([]AnnouncementDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   a.client.Domain,
-		Path:   announcementURL,
-	}
+	list, _, err := a.ListWithResponse(ctx, filter)
+	return list, err
+}
 
-	if filter != nil {
-		url.RawQuery = filter.QueryString()
+// ListWithResponse is the same as List but also returns the Freshservice API response
+func (a *AnnouncementServiceClient) ListWithResponse(ctx context.Context, filter QueryFilter) ([]AnnouncementDetails, *Response, error) {
+	req, err := a.client.newRequest(ctx, http.MethodGet, announcementURL, filter, nil)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+	res := &Announcements{}
+	resp, err := a.client.makeRequest(req, res)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	res := &Announcements{}
-	if _, err := a.client.makeRequest(req, res); err != nil {
-		return nil, err
+	return res.List, resp, nil
+}
+
+// Iter returns an iterator over every announcement matching the filter,
+// following the pagination links returned by Freshservice
+func (a *AnnouncementServiceClient) Iter(ctx context.Context, filter QueryFilter) *AnnouncementIterator {
+	it := &AnnouncementIterator{}
+	it.pager = newPager(ctx, filter, func(ctx context.Context, f QueryFilter) (*Response, error) {
+		list, resp, err := a.ListWithResponse(ctx, f)
+		it.page = list
+		return resp, err
+	})
+	return it
+}
+
+// ListAll returns every announcement matching the filter across all pages
+func (a *AnnouncementServiceClient) ListAll(ctx context.Context, filter QueryFilter) ([]AnnouncementDetails, error) {
+	var all []AnnouncementDetails
+	it := a.Iter(ctx, filter)
+	for it.Next() {
+		all = append(all, it.Value())
 	}
-	return res.List, nil
+	return all, it.Err()
+}
+
+// AnnouncementIterator iterates over Freshservice announcements page by page
+type AnnouncementIterator struct {
+	pager
+	page []AnnouncementDetails
+	cur  AnnouncementDetails
+}
+
+// Next advances the iterator to the next announcement. It returns
+// false when there are none left or an error occurred.
+func (it *AnnouncementIterator) Next() bool {
+	for len(it.page) == 0 {
+		if !it.fetchNext() {
+			return false
+		}
+	}
+
+	if !it.take() {
+		return false
+	}
+
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current announcement
+func (it *AnnouncementIterator) Value() AnnouncementDetails {
+	return it.cur
 }
 
 // Get a specific Freshservice announcement
 func (a *AnnouncementServiceClient) Get(ctx context.Context, id int) (*AnnouncementDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   a.client.Domain,
-		Path:   fmt.Sprintf("%s/%d", announcementURL, id),
-	}
+	details, _, err := a.GetWithResponse(ctx, id)
+	return details, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+// GetWithResponse is the same as Get but also returns the Freshservice API response
+func (a *AnnouncementServiceClient) GetWithResponse(ctx context.Context, id int) (*AnnouncementDetails, *Response, error) {
+	req, err := a.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%d", announcementURL, id), nil, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &Announcement{}
-	if _, err := a.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := a.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return &res.Details, nil
+	return &res.Details, resp, nil
 }
 
 // Create a new announcement in Freshservice
 func (a *AnnouncementServiceClient) Create(ctx context.Context, details *AnnouncementDetails) (*AnnouncementDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   a.client.Domain,
-		Path:   announcementURL,
-	}
-
-	announcementContent, err := json.Marshal(details)
-	if err != nil {
-		return nil, err
-	}
-
-	body := bytes.NewReader(announcementContent)
+	details, _, err := a.CreateWithResponse(ctx, details)
+	return details, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url.String(), body)
+// CreateWithResponse is the same as Create but also returns the Freshservice API response
+func (a *AnnouncementServiceClient) CreateWithResponse(ctx context.Context, details *AnnouncementDetails) (*AnnouncementDetails, *Response, error) {
+	req, err := a.client.newRequest(ctx, http.MethodPost, announcementURL, nil, details)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &Announcement{}
-	if _, err := a.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := a.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return &res.Details, nil
+	return &res.Details, resp, nil
 }
 
 // Update an announcement in Freshservice
 func (a *AnnouncementServiceClient) Update(ctx context.Context, id int, details *AnnouncementDetails) (*AnnouncementDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   a.client.Domain,
-		Path:   fmt.Sprintf("%s/%d", announcementURL, id),
-	}
-
-	announcementContent, err := json.Marshal(details)
-	if err != nil {
-		return nil, err
-	}
-
-	body := bytes.NewReader(announcementContent)
+	details, _, err := a.UpdateWithResponse(ctx, id, details)
+	return details, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url.String(), body)
+// UpdateWithResponse is the same as Update but also returns the Freshservice API response
+func (a *AnnouncementServiceClient) UpdateWithResponse(ctx context.Context, id int, details *AnnouncementDetails) (*AnnouncementDetails, *Response, error) {
+	req, err := a.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%d", announcementURL, id), nil, details)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &Announcement{}
-	if _, err := a.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := a.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return &res.Details, nil
+	return &res.Details, resp, nil
 }
 
 // Delete an announcement in Freshservice
 func (a *AnnouncementServiceClient) Delete(ctx context.Context, id int) error {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   a.client.Domain,
-		Path:   fmt.Sprintf("%s/%d", announcementURL, id),
-	}
+	_, err := a.DeleteWithResponse(ctx, id)
+	return err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url.String(), nil)
+// DeleteWithResponse is the same as Delete but also returns the Freshservice API response
+func (a *AnnouncementServiceClient) DeleteWithResponse(ctx context.Context, id int) (*Response, error) {
+	req, err := a.client.newRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", announcementURL, id), nil, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if _, err := a.client.makeRequest(req, nil); err != nil {
-		return err
-	}
-	return nil
+	return a.client.makeRequest(req, nil)
 }
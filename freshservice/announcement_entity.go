@@ -1,7 +1,6 @@
 package freshservice
 
 import (
-	"fmt"
 	"time"
 )
 
@@ -38,9 +37,15 @@ type AnnouncementDetails struct {
 // when listing Freshservice announcements
 type AnnouncementListFilter struct {
 	State string
+	// Page selects the page and page size to return
+	Page *PageCursor
 }
 
 // QueryString allows the available filter items to meet the QueryFilter interface
 func (af *AnnouncementListFilter) QueryString() string {
-	return fmt.Sprintf("state=%s", af.State)
+	v := af.Page.values()
+	if af.State != "" {
+		v.Set("state", af.State)
+	}
+	return v.Encode()
 }
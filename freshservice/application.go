@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
-	"strings"
 )
 
 const applicationURL = "/api/v2/applications"
@@ -14,10 +12,18 @@ const applicationURL = "/api/v2/applications"
 // the application endpoints of the Freshservice API
 type ApplicationService interface {
 	List(context.Context, QueryFilter) ([]ApplicationDetails, string, error)
+	ListWithResponse(context.Context, QueryFilter) ([]ApplicationDetails, *Response, error)
+	ListAll(context.Context, QueryFilter) ([]ApplicationDetails, error)
+	Iter(context.Context, QueryFilter) *ApplicationIterator
+	Prefetch(context.Context, QueryFilter, *PrefetchOptions) *ApplicationPrefetcher
 	Get(context.Context, int64) (*ApplicationDetails, error)
+	GetWithResponse(context.Context, int64) (*ApplicationDetails, *Response, error)
 	ListLicenses(context.Context, int64) ([]LicensesDetails, error)
+	ListLicensesWithResponse(context.Context, int64) ([]LicensesDetails, *Response, error)
 	ListUsers(context.Context, int64) ([]ApplicationUserDetails, error)
+	ListUsersWithResponse(context.Context, int64) ([]ApplicationUserDetails, *Response, error)
 	ListInstallations(context.Context, int64) ([]ApplicationInstallationDetails, error)
+	ListInstallationsWithResponse(context.Context, int64) ([]ApplicationInstallationDetails, *Response, error)
 }
 
 // ApplicationServiceClient facilitates requests with the TicketService methods
@@ -29,127 +35,209 @@ type ApplicationServiceClient struct {
 // All the below requests are paginated to return only 30 tickets per page.
 // Append the parameter "page=[:page_no]" in the url to traverse through pages.
 func (a *ApplicationServiceClient) List(ctx context.Context, filter QueryFilter) ([]ApplicationDetails, string, error) {
-
-	url := &url.URL{
-		Scheme: "https",
-		Host:   a.client.Domain,
-		Path:   applicationURL,
+	list, resp, err := a.ListWithResponse(ctx, filter)
+	if err != nil {
+		return nil, "", err
 	}
 
-	if filter != nil {
-		url.RawQuery = filter.QueryString()
-	}
+	return list, HasNextPage(resp.Response), nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+// ListWithResponse is the same as List but also returns the Freshservice API response
+func (a *ApplicationServiceClient) ListWithResponse(ctx context.Context, filter QueryFilter) ([]ApplicationDetails, *Response, error) {
+	req, err := a.client.newRequest(ctx, http.MethodGet, applicationURL, filter, nil)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	res := &Applications{}
 	resp, err := a.client.makeRequest(req, res)
 	if err != nil {
-		return nil, "", err
+		return nil, resp, err
 	}
 
-	return res.List, HasNextPage(resp), nil
+	return res.List, resp, nil
 }
 
-// Get a specific all application
-func (a *ApplicationServiceClient) Get(ctx context.Context, appID int64) (*ApplicationDetails, error) {
+// Iter returns an iterator over every application matching the filter,
+// following the pagination links returned by Freshservice
+func (a *ApplicationServiceClient) Iter(ctx context.Context, filter QueryFilter) *ApplicationIterator {
+	it := &ApplicationIterator{}
+	it.pager = newPager(ctx, filter, func(ctx context.Context, f QueryFilter) (*Response, error) {
+		list, resp, err := a.ListWithResponse(ctx, f)
+		it.page = list
+		return resp, err
+	})
+	return it
+}
 
-	url := &url.URL{
-		Scheme: "https",
-		Host:   a.client.Domain,
-		Path:   fmt.Sprintf("%s/%d", applicationURL, appID),
+// ListAll returns every application matching the filter across all pages
+func (a *ApplicationServiceClient) ListAll(ctx context.Context, filter QueryFilter) ([]ApplicationDetails, error) {
+	var all []ApplicationDetails
+	it := a.Iter(ctx, filter)
+	for it.Next() {
+		all = append(all, it.Value())
 	}
+	return all, it.Err()
+}
+
+// ApplicationIterator iterates over Freshservice applications page by page
+type ApplicationIterator struct {
+	pager
+	page []ApplicationDetails
+	cur  ApplicationDetails
+}
+
+// Next advances the iterator to the next application. It returns
+// false when there are none left or an error occurred.
+func (it *ApplicationIterator) Next() bool {
+	for len(it.page) == 0 {
+		if !it.fetchNext() {
+			return false
+		}
+	}
+
+	if !it.take() {
+		return false
+	}
+
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current application
+func (it *ApplicationIterator) Value() ApplicationDetails {
+	return it.cur
+}
+
+// Prefetch requests the pages of applications matching the filter concurrently
+// and delivers them in page order through the Pages channel
+func (a *ApplicationServiceClient) Prefetch(ctx context.Context, filter QueryFilter, opts *PrefetchOptions) *ApplicationPrefetcher {
+	p := &ApplicationPrefetcher{pages: make(chan ApplicationPage)}
+	fetch := func(ctx context.Context, f QueryFilter) (interface{}, *Response, error) {
+		return a.ListWithResponse(ctx, f)
+	}
+	emit := func(ctx context.Context, page int, items interface{}) bool {
+		select {
+		case p.pages <- ApplicationPage{Page: page, Applications: items.([]ApplicationDetails)}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	go p.run(ctx, filter, opts, fetch, emit, func() { close(p.pages) })
+	return p
+}
+
+// ApplicationPage is a single page of applications delivered by a ApplicationPrefetcher
+type ApplicationPage struct {
+	Page         int
+	Applications []ApplicationDetails
+}
+
+// ApplicationPrefetcher fetches pages of applications concurrently
+type ApplicationPrefetcher struct {
+	prefetcher
+	pages chan ApplicationPage
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+// Pages returns the channel the pages are delivered on in page order. It
+// is closed once every page has been delivered or prefetching stopped,
+// after which Err reports why. Cancel the context to stop early.
+func (p *ApplicationPrefetcher) Pages() <-chan ApplicationPage {
+	return p.pages
+}
+
+// Get a specific all application
+func (a *ApplicationServiceClient) Get(ctx context.Context, appID int64) (*ApplicationDetails, error) {
+	details, _, err := a.GetWithResponse(ctx, appID)
+	return details, err
+}
+
+// GetWithResponse is the same as Get but also returns the Freshservice API response
+func (a *ApplicationServiceClient) GetWithResponse(ctx context.Context, appID int64) (*ApplicationDetails, *Response, error) {
+	req, err := a.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%d", applicationURL, appID), nil, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &Application{}
-	if _, err = a.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := a.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return &res.Details, nil
+	return &res.Details, resp, nil
 }
 
 // ListLicenses lists all the licenses for an application
 func (a *ApplicationServiceClient) ListLicenses(ctx context.Context, appID int64) ([]LicensesDetails, error) {
+	list, _, err := a.ListLicensesWithResponse(ctx, appID)
+	return list, err
+}
 
-	url := &url.URL{
-		Scheme: "https",
-		Host:   a.client.Domain,
-		Path:   fmt.Sprintf("%s/%d/licenses", applicationURL, appID),
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+// ListLicensesWithResponse is the same as ListLicenses but also returns the Freshservice API response
+func (a *ApplicationServiceClient) ListLicensesWithResponse(ctx context.Context, appID int64) ([]LicensesDetails, *Response, error) {
+	req, err := a.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%d/licenses", applicationURL, appID), nil, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &Licenses{}
-	if _, err = a.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := a.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return res.List, nil
+	return res.List, resp, nil
 }
 
 // ListUsers lists all the users of an application
 func (a *ApplicationServiceClient) ListUsers(ctx context.Context, appID int64) ([]ApplicationUserDetails, error) {
+	list, _, err := a.ListUsersWithResponse(ctx, appID)
+	return list, err
+}
 
-	url := &url.URL{
-		Scheme: "https",
-		Host:   a.client.Domain,
-		Path:   fmt.Sprintf("%s/%d/users", applicationURL, appID),
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+// ListUsersWithResponse is the same as ListUsers but also returns the Freshservice API response
+func (a *ApplicationServiceClient) ListUsersWithResponse(ctx context.Context, appID int64) ([]ApplicationUserDetails, *Response, error) {
+	req, err := a.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%d/users", applicationURL, appID), nil, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &ApplicationUsers{}
-	if _, err = a.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := a.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return res.List, nil
+	return res.List, resp, nil
 }
 
 // ListInstallations lists all the installations of an application
 func (a *ApplicationServiceClient) ListInstallations(ctx context.Context, appID int64) ([]ApplicationInstallationDetails, error) {
+	list, _, err := a.ListInstallationsWithResponse(ctx, appID)
+	return list, err
+}
 
-	url := &url.URL{
-		Scheme: "https",
-		Host:   a.client.Domain,
-		Path:   fmt.Sprintf("%s/%d/installations", applicationURL, appID),
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+// ListInstallationsWithResponse is the same as ListInstallations but also returns the Freshservice API response
+func (a *ApplicationServiceClient) ListInstallationsWithResponse(ctx context.Context, appID int64) ([]ApplicationInstallationDetails, *Response, error) {
+	req, err := a.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%d/installations", applicationURL, appID), nil, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &ApplicationInstallations{}
-	if _, err = a.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := a.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return res.List, nil
+	return res.List, resp, nil
 }
 
-// QueryString allows us to pass TicketListOptions as a QueryFilter and
+// QueryString allows us to pass ApplicationListOptions as a QueryFilter and
 // will return a new endpoint URL with query parameters attached
 func (opts *ApplicationListOptions) QueryString() string {
-	var qs []string
-
-	if opts.PageQuery != "" {
-		qs = append(qs, opts.PageQuery)
-	}
-
-	return strings.Join(qs, "&")
+	return pageValues(opts.Page, opts.PageQuery).Encode()
 }
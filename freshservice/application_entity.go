@@ -40,6 +40,10 @@ type AdditionalData struct {
 // ApplicationListOptions holds the available options that can be
 // passed when requesting a list of Freshservice Applications
 type ApplicationListOptions struct {
+	// Page selects the page and page size to return
+	Page *PageCursor
+	// Deprecated: PageQuery is the raw query returned by HasNextPage, only
+	// its page parameters are used. Use Page instead.
 	PageQuery string
 }
 
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
-	"strings"
 )
 
 const assetURL = "/api/v2/assets"
@@ -14,7 +12,12 @@ const assetURL = "/api/v2/assets"
 // the asset endpoints of the Freshservice API
 type AssetService interface {
 	List(context.Context, QueryFilter) ([]AssetDetails, string, error)
+	ListWithResponse(context.Context, QueryFilter) ([]AssetDetails, *Response, error)
+	ListAll(context.Context, QueryFilter) ([]AssetDetails, error)
+	Iter(context.Context, QueryFilter) *AssetIterator
+	Prefetch(context.Context, QueryFilter, *PrefetchOptions) *AssetPrefetcher
 	Get(context.Context, int) (*AssetDetails, error)
+	GetWithResponse(context.Context, int) (*AssetDetails, *Response, error)
 }
 
 // AssetServiceClient facilitates requests with the AssetService methods
@@ -25,70 +28,156 @@ type AssetServiceClient struct {
 // List all Assets
 // Append the parameter "page=[:page_no]" in the url to traverse through pages.
 func (a *AssetServiceClient) List(ctx context.Context, filter QueryFilter) ([]AssetDetails, string, error) {
-
-	url := &url.URL{
-		Scheme: "https",
-		Host:   a.client.Domain,
-		Path:   assetURL,
+	list, resp, err := a.ListWithResponse(ctx, filter)
+	if err != nil {
+		return nil, "", err
 	}
 
-	if filter != nil {
-		url.RawQuery = filter.QueryString()
-	}
+	return list, HasNextPage(resp.Response), nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+// ListWithResponse is the same as List but also returns the Freshservice API response
+func (a *AssetServiceClient) ListWithResponse(ctx context.Context, filter QueryFilter) ([]AssetDetails, *Response, error) {
+	req, err := a.client.newRequest(ctx, http.MethodGet, assetURL, filter, nil)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	res := &Assets{}
 	resp, err := a.client.makeRequest(req, res)
 	if err != nil {
-		return nil, "", err
+		return nil, resp, err
 	}
 
-	return res.List, HasNextPage(resp), nil
+	return res.List, resp, nil
 }
 
-// Get a specific asset
-func (a *AssetServiceClient) Get(ctx context.Context, assetID int) (*AssetDetails, error) {
+// Iter returns an iterator over every asset matching the filter,
+// following the pagination links returned by Freshservice
+func (a *AssetServiceClient) Iter(ctx context.Context, filter QueryFilter) *AssetIterator {
+	it := &AssetIterator{}
+	it.pager = newPager(ctx, filter, func(ctx context.Context, f QueryFilter) (*Response, error) {
+		list, resp, err := a.ListWithResponse(ctx, f)
+		it.page = list
+		return resp, err
+	})
+	return it
+}
+
+// ListAll returns every asset matching the filter across all pages
+func (a *AssetServiceClient) ListAll(ctx context.Context, filter QueryFilter) ([]AssetDetails, error) {
+	var all []AssetDetails
+	it := a.Iter(ctx, filter)
+	for it.Next() {
+		all = append(all, it.Value())
+	}
+	return all, it.Err()
+}
+
+// AssetIterator iterates over Freshservice assets page by page
+type AssetIterator struct {
+	pager
+	page []AssetDetails
+	cur  AssetDetails
+}
+
+// Next advances the iterator to the next asset. It returns
+// false when there are none left or an error occurred.
+func (it *AssetIterator) Next() bool {
+	for len(it.page) == 0 {
+		if !it.fetchNext() {
+			return false
+		}
+	}
+
+	if !it.take() {
+		return false
+	}
 
-	url := &url.URL{
-		Scheme: "https",
-		Host:   a.client.Domain,
-		Path:   fmt.Sprintf("%s/%d", assetURL, assetID),
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current asset
+func (it *AssetIterator) Value() AssetDetails {
+	return it.cur
+}
+
+// Prefetch requests the pages of assets matching the filter concurrently
+// and delivers them in page order through the Pages channel
+func (a *AssetServiceClient) Prefetch(ctx context.Context, filter QueryFilter, opts *PrefetchOptions) *AssetPrefetcher {
+	p := &AssetPrefetcher{pages: make(chan AssetPage)}
+	fetch := func(ctx context.Context, f QueryFilter) (interface{}, *Response, error) {
+		return a.ListWithResponse(ctx, f)
+	}
+	emit := func(ctx context.Context, page int, items interface{}) bool {
+		select {
+		case p.pages <- AssetPage{Page: page, Assets: items.([]AssetDetails)}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
 	}
+	go p.run(ctx, filter, opts, fetch, emit, func() { close(p.pages) })
+	return p
+}
+
+// AssetPage is a single page of assets delivered by a AssetPrefetcher
+type AssetPage struct {
+	Page   int
+	Assets []AssetDetails
+}
+
+// AssetPrefetcher fetches pages of assets concurrently
+type AssetPrefetcher struct {
+	prefetcher
+	pages chan AssetPage
+}
+
+// Pages returns the channel the pages are delivered on in page order. It
+// is closed once every page has been delivered or prefetching stopped,
+// after which Err reports why. Cancel the context to stop early.
+func (p *AssetPrefetcher) Pages() <-chan AssetPage {
+	return p.pages
+}
+
+// Get a specific asset
+func (a *AssetServiceClient) Get(ctx context.Context, assetID int) (*AssetDetails, error) {
+	details, _, err := a.GetWithResponse(ctx, assetID)
+	return details, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+// GetWithResponse is the same as Get but also returns the Freshservice API response
+func (a *AssetServiceClient) GetWithResponse(ctx context.Context, assetID int) (*AssetDetails, *Response, error) {
+	req, err := a.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%d", assetURL, assetID), nil, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &Asset{}
-	if _, err = a.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := a.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return &res.Details, nil
+	return &res.Details, resp, nil
 }
 
 // QueryString allows us to pass AssetListOptions as a QueryFilter and
 // will return a new endpoint URL with query parameters attached
 func (opts *AssetListOptions) QueryString() string {
-	var qs []string
+	v := pageValues(opts.Page, opts.PageQuery)
 
-	if opts.PageQuery != "" {
-		qs = append(qs, opts.PageQuery)
-	}
+	opts.SortBy.setValues(v)
 
 	if opts.Embed != nil {
 		if opts.Embed.TypeFields {
-			qs = append(qs, "include=type_fields")
+			v.Set("include", "type_fields")
 		}
 		if opts.Embed.Trashed {
-			qs = append(qs, fmt.Sprintf("trashed=%v", opts.Embed.Trashed))
+			v.Set("trashed", "true")
 		}
 	}
 
-	return strings.Join(qs, "&")
+	return v.Encode()
 }
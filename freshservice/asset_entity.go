@@ -35,6 +35,10 @@ type AssetDetails struct {
 // AssetListOptions holds the available options that can be
 // passed when requesting a list of Freshservice assets
 type AssetListOptions struct {
+	// Page selects the page and page size to return
+	Page *PageCursor
+	// Deprecated: PageQuery is the raw query returned by HasNextPage, only
+	// its page parameters are used. Use Page instead.
 	PageQuery string
 	SortBy    *SortOptions
 	Embed     *AssetEmbedOptions
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
 )
 
 const businessHoursURL = "/api/v2/business_hours"
@@ -13,7 +12,11 @@ const businessHoursURL = "/api/v2/business_hours"
 // the business hours endpoints of the Freshservice API
 type BusinessHoursService interface {
 	List(context.Context) ([]BusinessHoursDetails, error)
+	ListWithResponse(context.Context) ([]BusinessHoursDetails, *Response, error)
+	ListAll(context.Context) ([]BusinessHoursDetails, error)
+	Iter(context.Context) *BusinessHoursIterator
 	Get(context.Context, int) (*BusinessHoursDetails, error)
+	GetWithResponse(context.Context, int) (*BusinessHoursDetails, *Response, error)
 }
 
 // BusinessHoursServiceClient facilitates requests with the AnnouncementService methods
@@ -23,42 +26,101 @@ type BusinessHoursServiceClient struct {
 
 // List all business hours configured in Freshservice
 func (c *BusinessHoursServiceClient) List(ctx context.Context) ([]BusinessHoursDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   c.client.Domain,
-		Path:   businessHoursURL,
-	}
+	list, _, err := c.ListWithResponse(ctx)
+	return list, err
+}
+
+// ListWithResponse is the same as List but also returns the Freshservice API response
+func (c *BusinessHoursServiceClient) ListWithResponse(ctx context.Context) ([]BusinessHoursDetails, *Response, error) {
+	return c.list(ctx, nil)
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+// list requests a page of business hours configurations
+func (c *BusinessHoursServiceClient) list(ctx context.Context, filter QueryFilter) ([]BusinessHoursDetails, *Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodGet, businessHoursURL, filter, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &BusinessHours{}
-	if _, err := c.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := c.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return res.List, resp, nil
+}
+
+// Iter returns an iterator over every business hours configuration,
+// following the pagination links returned by Freshservice
+func (c *BusinessHoursServiceClient) Iter(ctx context.Context) *BusinessHoursIterator {
+	it := &BusinessHoursIterator{}
+	it.pager = newPager(ctx, nil, func(ctx context.Context, f QueryFilter) (*Response, error) {
+		list, resp, err := c.list(ctx, f)
+		it.page = list
+		return resp, err
+	})
+	return it
+}
+
+// ListAll returns every business hours configuration across all pages
+func (c *BusinessHoursServiceClient) ListAll(ctx context.Context) ([]BusinessHoursDetails, error) {
+	var all []BusinessHoursDetails
+	it := c.Iter(ctx)
+	for it.Next() {
+		all = append(all, it.Value())
 	}
+	return all, it.Err()
+}
 
-	return res.List, nil
+// BusinessHoursIterator iterates over Freshservice business
+// hours configurations page by page
+type BusinessHoursIterator struct {
+	pager
+	page []BusinessHoursDetails
+	cur  BusinessHoursDetails
+}
+
+// Next advances the iterator to the next business hours configuration. It returns
+// false when there are none left or an error occurred.
+func (it *BusinessHoursIterator) Next() bool {
+	for len(it.page) == 0 {
+		if !it.fetchNext() {
+			return false
+		}
+	}
+
+	if !it.take() {
+		return false
+	}
+
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current business hours configuration
+func (it *BusinessHoursIterator) Value() BusinessHoursDetails {
+	return it.cur
 }
 
 // Get a details for a specific business hour configuration in Freshservice
 func (c *BusinessHoursServiceClient) Get(ctx context.Context, id int) (*BusinessHoursDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   c.client.Domain,
-		Path:   fmt.Sprintf("%s/%d", businessHoursURL, id),
-	}
+	details, _, err := c.GetWithResponse(ctx, id)
+	return details, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+// GetWithResponse is the same as Get but also returns the Freshservice API response
+func (c *BusinessHoursServiceClient) GetWithResponse(ctx context.Context, id int) (*BusinessHoursDetails, *Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%d", businessHoursURL, id), nil, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &BusinessHoursConfig{}
-	if _, err := c.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := c.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return &res.Details, nil
+	return &res.Details, resp, nil
 }
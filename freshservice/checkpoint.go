@@ -0,0 +1,288 @@
+package freshservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Checkpoint records the progress of a resumable list traversal
+type Checkpoint struct {
+	// Page is the last page whose items were all returned
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+	// Query is the encoded filter of the traversal without page parameters
+	Query string `json:"query"`
+	// Filter is the JSON encoded list options used to resume the traversal
+	Filter json.RawMessage `json:"filter,omitempty"`
+	// SeenIDs are the IDs returned from the last page, used to skip
+	// items that shifted onto the next page while the traversal was stopped
+	SeenIDs   []int     `json:"seen_ids,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CheckpointStore persists the checkpoints of resumable list traversals
+type CheckpointStore interface {
+	// Load returns the checkpoint saved under key, or nil if there is none
+	Load(ctx context.Context, key string) (*Checkpoint, error)
+	// Save replaces the checkpoint saved under key
+	Save(ctx context.Context, key string, cp *Checkpoint) error
+	// Delete removes the checkpoint saved under key, if any
+	Delete(ctx context.Context, key string) error
+}
+
+// FileCheckpointStore saves checkpoints as JSON files in a directory
+type FileCheckpointStore struct {
+	Dir string
+}
+
+// NewFileCheckpointStore returns a store saving checkpoints in dir,
+// creating the directory if it does not exist
+func NewFileCheckpointStore(dir string) (*FileCheckpointStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating checkpoint directory: %w", err)
+	}
+	return &FileCheckpointStore{Dir: dir}, nil
+}
+
+// Load satisfies the CheckpointStore interface
+func (s *FileCheckpointStore) Load(ctx context.Context, key string) (*Checkpoint, error) {
+	b, err := ioutil.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading checkpoint %q: %w", key, err)
+	}
+
+	cp := &Checkpoint{}
+	if err := json.Unmarshal(b, cp); err != nil {
+		return nil, fmt.Errorf("error decoding checkpoint %q: %w", key, err)
+	}
+	return cp, nil
+}
+
+// Save satisfies the CheckpointStore interface. The checkpoint is written
+// to a temporary file first so a crash never leaves a partial checkpoint.
+func (s *FileCheckpointStore) Save(ctx context.Context, key string, cp *Checkpoint) error {
+	b, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("error encoding checkpoint %q: %w", key, err)
+	}
+
+	tmp, err := ioutil.TempFile(s.Dir, ".checkpoint-*")
+	if err != nil {
+		return fmt.Errorf("error saving checkpoint %q: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error saving checkpoint %q: %w", key, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error saving checkpoint %q: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error saving checkpoint %q: %w", key, err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return fmt.Errorf("error saving checkpoint %q: %w", key, err)
+	}
+	return nil
+}
+
+// Delete satisfies the CheckpointStore interface
+func (s *FileCheckpointStore) Delete(ctx context.Context, key string) error {
+	err := os.Remove(s.path(key))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error deleting checkpoint %q: %w", key, err)
+	}
+	return nil
+}
+
+// path returns the file a checkpoint is saved in
+func (s *FileCheckpointStore) path(key string) string {
+	return filepath.Join(s.Dir, url.PathEscape(key)+".json")
+}
+
+// baseQuery returns the query of a filter without its page parameters
+func baseQuery(filter QueryFilter) string {
+	return (&cursorFilter{filter: filter, cursor: &PageCursor{}}).QueryString()
+}
+
+// resumer holds the state shared by resumable iterators. A checkpoint is
+// saved as soon as the last item of a page has been returned, and deleted
+// once the last item of the traversal has been returned. When resuming, the last completed page is
+// requested again and any item already returned from it is skipped, so
+// items that shifted by less than a page while the traversal was
+// stopped are neither missed nor returned twice. The items of a page
+// that was only partly consumed are returned again.
+type resumer struct {
+	ctx     context.Context
+	store   CheckpointStore
+	key     string
+	filter  QueryFilter
+	fetch   fetchFunc
+	cp      Checkpoint
+	page    int
+	prev    map[int]bool
+	cur     map[int]bool
+	started bool
+	resumed bool
+	unsaved bool
+	last    bool
+	done    bool
+	err     error
+}
+
+// newResumer returns a resumer continuing from the checkpoint saved
+// under key, if any, which is also returned so the typed iterator can
+// decode the saved filter. A nil filter resumes with the saved filter.
+func newResumer(ctx context.Context, store CheckpointStore, key string, filter QueryFilter) (resumer, *Checkpoint, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	r := resumer{ctx: ctx, store: store, key: key, filter: filter, prev: map[int]bool{}, cur: map[int]bool{}}
+
+	saved, err := store.Load(ctx, key)
+	if err != nil {
+		return r, nil, err
+	}
+
+	if saved == nil {
+		raw, err := json.Marshal(filter)
+		if err != nil {
+			return r, nil, fmt.Errorf("error encoding checkpoint filter: %w", err)
+		}
+		r.cp = Checkpoint{PerPage: MaxPerPage, Query: baseQuery(filter), Filter: raw}
+		return r, nil, nil
+	}
+
+	if filter != nil && baseQuery(filter) != saved.Query {
+		return r, nil, ErrCheckpointMismatch
+	}
+
+	r.cp = *saved
+	for _, id := range saved.SeenIDs {
+		r.prev[id] = true
+	}
+	return r, saved, nil
+}
+
+// SetPerPage sets the number of items requested per page, up to
+// MaxPerPage. It only applies to new traversals, a resumed traversal
+// keeps the page size it was started with so page numbers line up.
+func (r *resumer) SetPerPage(n int) {
+	if r.cp.Page > 0 || r.started {
+		return
+	}
+	if n <= 0 || n > MaxPerPage {
+		n = MaxPerPage
+	}
+	r.cp.PerPage = n
+}
+
+// Err returns the first error encountered while iterating
+func (r *resumer) Err() error {
+	return r.err
+}
+
+// Checkpoint returns a copy of the last checkpoint saved
+func (r *resumer) Checkpoint() Checkpoint {
+	return r.cp
+}
+
+// seen records the ID of an item on the current page, reporting whether
+// it was already returned from this page or the previous one
+func (r *resumer) seen(id int) bool {
+	dup := r.prev[id] || r.cur[id]
+	r.cur[id] = true
+	return dup
+}
+
+// consumed checkpoints the current page once all of its items have
+// been returned, deleting the checkpoint after the last page
+func (r *resumer) consumed() {
+	if !r.unsaved || r.err != nil {
+		return
+	}
+	r.unsaved = false
+
+	if r.last {
+		r.done = true
+		r.err = r.store.Delete(r.ctx, r.key)
+		return
+	}
+
+	// items of the checkpointed page may have shifted onto the next one
+	if r.resumed {
+		for id := range r.prev {
+			r.cur[id] = true
+		}
+		r.resumed = false
+	}
+
+	if r.err = r.save(); r.err != nil {
+		return
+	}
+	r.prev, r.cur = r.cur, map[int]bool{}
+}
+
+// fetchNext checkpoints the current page if it has not been already and
+// requests the next one, returning false once there are no more pages
+// or an error occurred
+func (r *resumer) fetchNext() bool {
+	r.consumed()
+	if r.done || r.err != nil {
+		return false
+	}
+
+	if err := r.ctx.Err(); err != nil {
+		r.err = err
+		return false
+	}
+
+	// a resumed traversal starts again from the last completed page
+	r.page = r.cp.Page + 1
+	if !r.started && r.cp.Page > 0 {
+		r.page = r.cp.Page
+		r.resumed = true
+	}
+	r.started = true
+
+	resp, err := r.fetch(r.ctx, withCursor(r.filter, &PageCursor{Page: r.page, PerPage: r.cp.PerPage}))
+	if err != nil {
+		r.err = err
+		return false
+	}
+	r.last = resp.Next == nil
+	r.unsaved = true
+
+	return true
+}
+
+// save checkpoints the current page
+func (r *resumer) save() error {
+	ids := make([]int, 0, len(r.cur))
+	for id := range r.cur {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	r.cp.Page = r.page
+	r.cp.SeenIDs = ids
+	r.cp.UpdatedAt = time.Now().UTC()
+
+	cp := r.cp
+	return r.store.Save(r.ctx, r.key, &cp)
+}
@@ -0,0 +1,167 @@
+package freshservice_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CoreyGriffin/go-freshservice/freshservice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTicketIterResumable(t *testing.T) {
+	ids := []int{1, 2, 3, 4, 5, 6}
+	var queries []string
+
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		start, end := (page-1)*perPage, page*perPage
+		if end < len(ids) {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=%d&per_page=%d>; rel="next"`, r.URL.Path, page+1, perPage))
+		} else {
+			end = len(ids)
+		}
+
+		var tickets []string
+		for _, id := range ids[start:end] {
+			tickets = append(tickets, fmt.Sprintf(`{"id":%d}`, id))
+		}
+		fmt.Fprintf(w, `{"tickets":[%s]}`, strings.Join(tickets, ","))
+	})
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "checkpoints")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := freshservice.NewFileCheckpointStore(dir)
+	assert.Nil(t, err)
+
+	since := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	opts := &freshservice.TicketListOptions{FilterBy: &freshservice.TicketFilter{UpdatedSince: &since}}
+
+	it, err := c.Tickets().IterResumable(context.Background(), "export", opts, store)
+	assert.Nil(t, err)
+	it.SetPerPage(2)
+
+	// stop part way through the second page
+	var got []int
+	for i := 0; i < 3 && it.Next(); i++ {
+		got = append(got, it.Value().ID)
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	cp, err := store.Load(context.Background(), "export")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, cp.Page)
+	assert.Equal(t, 2, cp.PerPage)
+	assert.Equal(t, []int{1, 2}, cp.SeenIDs)
+	assert.Contains(t, cp.Query, "updated_since=")
+
+	// a new ticket shifts every other ticket along while stopped
+	ids = append([]int{7}, ids...)
+	queries = nil
+
+	_, err = c.Tickets().IterResumable(context.Background(), "export", &freshservice.TicketListOptions{}, store)
+	assert.True(t, errors.Is(err, freshservice.ErrCheckpointMismatch))
+
+	it, err = c.Tickets().IterResumable(context.Background(), "export", nil, store)
+	assert.Nil(t, err)
+
+	got = nil
+	for it.Next() {
+		got = append(got, it.Value().ID)
+	}
+	assert.Nil(t, it.Err())
+
+	// ticket 2 shifted onto the second page and is not returned again,
+	// ticket 3 is as its page was not completed
+	assert.Equal(t, []int{7, 3, 4, 5, 6}, got)
+	assert.Len(t, queries, 4)
+	assert.True(t, strings.HasPrefix(queries[0], "page=1&per_page=2&updated_since="))
+
+	cp, err = store.Load(context.Background(), "export")
+	assert.Nil(t, err)
+	assert.Nil(t, cp)
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	assert.Empty(t, files)
+}
+
+func TestTicketIterResumableEndOfPage(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=2&per_page=2>; rel="next"`, r.URL.Path))
+			fmt.Fprint(w, `{"tickets":[{"id":1},{"id":2}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"tickets":[{"id":3}]}`)
+	})
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "checkpoints")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := freshservice.NewFileCheckpointStore(dir)
+	assert.Nil(t, err)
+
+	it, err := c.Tickets().IterResumable(context.Background(), "export", nil, store)
+	assert.Nil(t, err)
+	it.SetPerPage(2)
+
+	// the page is checkpointed when its last ticket is returned,
+	// without waiting for the next page to be requested
+	assert.True(t, it.Next())
+	assert.True(t, it.Next())
+	cp, err := store.Load(context.Background(), "export")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, cp.Page)
+	assert.Equal(t, []int{1, 2}, cp.SeenIDs)
+
+	// and deleted when the last ticket is returned
+	assert.True(t, it.Next())
+	assert.Equal(t, 3, it.Value().ID)
+	cp, err = store.Load(context.Background(), "export")
+	assert.Nil(t, err)
+	assert.Nil(t, cp)
+
+	assert.False(t, it.Next())
+	assert.Nil(t, it.Err())
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoints")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := freshservice.NewFileCheckpointStore(filepath.Join(dir, "nested"))
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	cp, err := store.Load(ctx, "tickets/nightly")
+	assert.Nil(t, err)
+	assert.Nil(t, cp)
+
+	assert.Nil(t, store.Save(ctx, "tickets/nightly", &freshservice.Checkpoint{Page: 900, PerPage: 100, SeenIDs: []int{1}}))
+
+	cp, err = store.Load(ctx, "tickets/nightly")
+	assert.Nil(t, err)
+	assert.Equal(t, 900, cp.Page)
+	assert.Equal(t, []int{1}, cp.SeenIDs)
+
+	assert.Nil(t, store.Delete(ctx, "tickets/nightly"))
+	assert.Nil(t, store.Delete(ctx, "tickets/nightly"))
+}
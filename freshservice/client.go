@@ -1,15 +1,29 @@
 package freshservice
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
-	"os"
+	"net/url"
 	"strings"
 	"time"
 )
 
+const (
+	defaultUserAgent = "go-freshservice"
+	// all requests go to a single host so most idle connections are kept for it
+	maxIdleConnsPerHost = 32
+	// bodies larger than this are closed rather than drained for reuse
+	maxDrainBytes = 64 << 10
+	// number of bytes of an unexpected response body included in errors
+	maxErrorSnippet = 256
+)
+
 // Client represents a new Freshservice API client to
 // be utilized for API requests
 type Client struct {
@@ -20,8 +34,29 @@ type Client struct {
 
 	// Basic Authentication requried for Freshservice API calls
 	Auth *BasicAuth
+	// RetryPolicy controls retries of rate limited and failed requests.
+	// Retries are disabled when nil.
+	RetryPolicy *RetryPolicy
+	// CreditLimiter budgets the API credits spent by the client.
+	// Requests are not limited client side when nil.
+	CreditLimiter *CreditLimiter
+
 	// API client to utilize for making HTTP requests
 	client *http.Client
+	// URL that API paths are resolved against
+	baseURL *url.URL
+	// User-Agent header sent with every request
+	userAgent string
+	// Optional logger, nothing is logged when nil
+	logger Logger
+	// Controls what is recorded when traffic is logged
+	logOptions LogOptions
+	// Logs traffic when a logger is set
+	trafficLog *trafficLogger
+	// Overrides the HTTP client timeout when set
+	timeout time.Duration
+	// Middleware run for every request
+	middleware []Middleware
 }
 
 // BasicAuth holds the basic auth requirements needed to
@@ -33,17 +68,34 @@ type BasicAuth struct {
 // Used if custom client not passed in when NewClient instantiated
 func defaultHTTPClient() *http.Client {
 	return &http.Client{
-		Timeout: time.Minute,
+		Timeout:   time.Minute,
+		Transport: defaultTransport(),
 	}
 }
 
-// New returns a new Freshservice API client that can be used for both V1 and V2 of the Freshservice API
-func New(ctx context.Context, domain string, apikey string, client *http.Client) (*Client, error) {
-
-	if ctx == nil {
-		ctx = context.Background()
+// defaultTransport keeps a pool of idle connections to Freshservice so
+// consecutive requests reuse connections instead of paying for a new
+// TCP and TLS handshake each time
+func defaultTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
 	}
+}
 
+// NewClient returns a new Freshservice API client for the given domain and
+// API key. By default requests are sent to https://[domain] using a HTTP
+// client with a timeout of 1 minute, this can be changed with options.
+func NewClient(domain string, apikey string, opts ...Option) (*Client, error) {
 	// handle required attributes
 	if domain == "" {
 		return nil, missingClientConfigErr("domain")
@@ -53,61 +105,270 @@ func New(ctx context.Context, domain string, apikey string, client *http.Client)
 		return nil, missingClientConfigErr("API key")
 	}
 
-	// default to HTTP client if one is not provided
-	if client == nil {
-		client = defaultHTTPClient()
-		client.Timeout = time.Minute * 5
-	}
+	domain = stripURLScheme(domain)
 
-	return &Client{
-		Domain:  stripURLScheme(domain),
-		Context: ctx,
+	fs := &Client{
+		Domain:  domain,
+		Context: context.Background(),
 		Auth: &BasicAuth{
 			APIKey: apikey,
 		},
-		client: client,
-	}, nil
+		client:    defaultHTTPClient(),
+		baseURL:   &url.URL{Scheme: "https", Host: domain},
+		userAgent: defaultUserAgent,
+	}
+
+	for _, opt := range opts {
+		if err := opt(fs); err != nil {
+			return nil, err
+		}
+	}
+
+	// copy the HTTP client so a client passed in by the caller is not modified
+	if fs.timeout > 0 {
+		client := *fs.client
+		client.Timeout = fs.timeout
+		fs.client = &client
+	}
+
+	if fs.logger != nil {
+		fs.trafficLog = newTrafficLogger(fs.logger, fs.logOptions)
+	}
+
+	return fs, nil
+}
+
+// New returns a new Freshservice API client that can be used for both V1 and V2 of the Freshservice API
+func New(ctx context.Context, domain string, apikey string, client *http.Client) (*Client, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// default to HTTP client if one is not provided
+	opt := WithTimeout(time.Minute * 5)
+	if client != nil {
+		opt = WithHTTPClient(client)
+	}
+
+	fs, err := NewClient(domain, apikey, opt)
+	if err != nil {
+		return nil, err
+	}
+	fs.Context = ctx
+
+	return fs, nil
+}
+
+// newRequest builds a request for an API path relative to the client's
+// base URL, encoding the body as JSON when one is provided
+func (fs *Client) newRequest(ctx context.Context, method string, path string, filter QueryFilter, body interface{}) (*http.Request, error) {
+	u := *fs.baseURL
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+
+	if filter != nil {
+		u.RawQuery = filter.QueryString()
+	}
+
+	var content io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding %s %s request body: %w", method, path, err)
+		}
+		content = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), content)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return req, nil
 }
 
 // makeRequest is used internally by the Freshservice API client to
 // make an API request and unmarshal into the response interface passed in
-func (fs *Client) makeRequest(r *http.Request, v interface{}) (*http.Response, error) {
+func (fs *Client) makeRequest(r *http.Request, v interface{}) (resp *Response, err error) {
 	r.Header.Set("Accept", "application/json")
 	r.Header.Set("Cache-Control", "no-store, no-cache, must-revalidate, max-age=0, post-check=0, pre-check=0")
 	r.Header.Set("Strict-Transport-Security", "max-age=31536000 ; includeSubDomains")
+	r.Header.Set("User-Agent", fs.userAgent)
 	r.SetBasicAuth(fs.Auth.APIKey, "x")
 
-	// Replace scheme for unit tests that are using a mock server
-	if os.Getenv("GO_TEST") == "1" {
-		r.URL.Scheme = "http"
-	}
-
-	r.Close = true
-
-	res, err := fs.client.Do(r)
+	res, err := fs.do(r)
 	if err != nil {
-		return nil, fmt.Errorf("error making %s request to %s", r.Method, r.URL)
+		return nil, err
 	}
 
+	// a close failure after v is decoded does not lose the result
+	decoded := false
 	defer func() {
-		if err := res.Body.Close(); err != nil {
-			panic(err)
+		// drain anything left unread so the connection can be reused
+		io.Copy(ioutil.Discard, io.LimitReader(res.Body, maxDrainBytes))
+		if cerr := res.Body.Close(); cerr != nil && err == nil && !decoded {
+			err = fmt.Errorf("error closing %s %s response body: %w", r.Method, r.URL, cerr)
 		}
 	}()
 
-	if res.StatusCode == http.StatusNotFound {
-		return res, fmt.Errorf("%s %s not found", r.Method, r.URL)
-	}
+	resp = newResponse(res)
 
 	if res.StatusCode < http.StatusOK || res.StatusCode > 299 {
-		v = ErrorResponse{}
+		return resp, newAPIError(r, res)
+	}
+
+	if v == nil || res.StatusCode == http.StatusNoContent {
+		return resp, nil
+	}
+
+	// keep the start of the body so decode failures can be diagnosed
+	snippet := &snippetBuffer{max: maxErrorSnippet}
+	if err := json.NewDecoder(io.TeeReader(res.Body, snippet)).Decode(v); err != nil {
+		return resp, fmt.Errorf("error decoding %s %s response: %w (body: %q)", r.Method, r.URL, err, snippet.String())
+	}
+	decoded = true
+
+	return resp, nil
+}
+
+// do sends the request, retrying it according to the client's RetryPolicy
+// when Freshservice is rate limiting or temporarily unavailable
+func (fs *Client) do(r *http.Request) (*http.Response, error) {
+	return fs.doWith(fs.client, fs.RetryPolicy, r)
+}
+
+// doWith sends the request with the given HTTP client and retry policy.
+// Only requests to the Freshservice API spend API credits and go through
+// the middleware chain, requests to other hosts, such as the storage host
+// of an attachment, are only retried.
+func (fs *Client) doWith(c *http.Client, policy *RetryPolicy, r *http.Request) (*http.Response, error) {
+	apiHost := fs.isAPIHost(r.URL)
+
+	for attempt := 1; ; attempt++ {
+		if fs.CreditLimiter != nil && apiHost {
+			if err := fs.CreditLimiter.Wait(r.Context(), requestCredits(r)); err != nil {
+				// the body is closed as the transport would have, which
+				// also stops a streaming multipart body from being written
+				if r.Body != nil {
+					r.Body.Close()
+				}
+				return nil, fmt.Errorf("error waiting for API credits for %s request to %s: %w", r.Method, r.URL, err)
+			}
+		}
+
+		var res *http.Response
+		var err error
+		if apiHost {
+			res, err = fs.roundTrip(c, r)
+		} else {
+			res, err = c.Do(r)
+		}
+		if apiHost && fs.CreditLimiter != nil {
+			if err != nil {
+				fs.CreditLimiter.release(requestCredits(r))
+			} else {
+				fs.CreditLimiter.Sync(res.Header)
+			}
+		}
+
+		if !policy.shouldRetry(r, res, err, attempt) {
+			if err != nil {
+				return nil, fmt.Errorf("error making %s request to %s: %w", r.Method, r.URL, err)
+			}
+			return res, nil
+		}
+
+		wait := policy.backoff(attempt, res)
+		if fs.logger != nil {
+			fs.logger.Warn("retrying Freshservice request", "method", r.Method, "path", r.URL.Path, "attempt", attempt, "wait", wait)
+		}
+		if res != nil {
+			discardBody(res.Body)
+		}
+
+		if err := sleepContext(r.Context(), wait); err != nil {
+			return nil, fmt.Errorf("error retrying %s request to %s: %w", r.Method, r.URL, err)
+		}
+
+		next, err := rewindRequest(r)
+		if err != nil {
+			return nil, fmt.Errorf("error rewinding %s request to %s: %w", r.Method, r.URL, err)
+		}
+		r = next
+	}
+}
+
+// isAPIHost reports whether a URL points at the Freshservice API the
+// client sends its credentials to
+func (fs *Client) isAPIHost(u *url.URL) bool {
+	return strings.EqualFold(u.Scheme, fs.baseURL.Scheme) && strings.EqualFold(u.Host, fs.baseURL.Host)
+}
+
+// newAPIError builds an APIError from a failed response, decoding
+// the Freshservice error body when one is present
+func newAPIError(r *http.Request, res *http.Response) error {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		Method:     r.Method,
+		URL:        r.URL.String(),
+		RequestID:  res.Header.Get("X-Request-Id"),
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(res.Body, maxDrainBytes))
+	if err != nil || len(body) == 0 {
+		return apiErr
 	}
 
-	if v == nil {
-		return res, nil
+	errRes := &ErrorResponse{}
+	if err := json.Unmarshal(body, errRes); err != nil {
+		// not a Freshservice error body, e.g. a HTML page from a proxy
+		apiErr.Description = truncate(string(body), maxErrorSnippet)
+		return apiErr
 	}
 
-	return res, json.NewDecoder(res.Body).Decode(&v)
+	apiErr.Description = errRes.Description
+	apiErr.Errors = errRes.Errors
+
+	return apiErr
+}
+
+// snippetBuffer keeps the first max bytes written to it
+type snippetBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+// Write satisfies io.Writer, discarding anything past the limit
+func (b *snippetBuffer) Write(p []byte) (int, error) {
+	room := b.max - b.buf.Len()
+	if len(p) > room {
+		b.buf.Write(p[:room])
+		b.truncated = true
+		return len(p), nil
+	}
+	b.buf.Write(p)
+	return len(p), nil
+}
+
+// String returns the kept bytes, marking them if the body was longer
+func (b *snippetBuffer) String() string {
+	if b.truncated {
+		return b.buf.String() + "..."
+	}
+	return b.buf.String()
+}
+
+// truncate shortens s to at most n bytes, marking that it was cut
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
 }
 
 // We set the scheme in the HTTP request
@@ -156,3 +417,18 @@ func (fs *Client) BusinessHours() BusinessHoursService {
 func (fs *Client) Tasks() TaskService {
 	return &TaskServiceClient{client: fs}
 }
+
+// Conversations is the interface between the HTTP client and the Freshservice ticket conversation related endpoints
+func (fs *Client) Conversations() ConversationService {
+	return &ConversationServiceClient{client: fs}
+}
+
+// TimeEntries is the interface between the HTTP client and the Freshservice ticket time entry related endpoints
+func (fs *Client) TimeEntries() TimeEntryService {
+	return &TimeEntryServiceClient{client: fs}
+}
+
+// TicketFields is the interface between the HTTP client and the Freshservice ticket form field related endpoints
+func (fs *Client) TicketFields() TicketFieldService {
+	return &TicketFieldServiceClient{client: fs}
+}
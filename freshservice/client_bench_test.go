@@ -0,0 +1,90 @@
+package freshservice
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTLSBenchServer starts a TLS test server that counts new connections
+// and returns a client using the default transport configured to trust it
+func newTLSBenchServer(tb testing.TB, conns *int64, opts ...Option) (*httptest.Server, *Client) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ticket":{"id":1,"subject":"benchmark"}}`)
+	}))
+	server.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt64(conns, 1)
+		}
+	}
+	server.StartTLS()
+
+	transport := defaultTransport()
+	transport.TLSClientConfig = &tls.Config{RootCAs: server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs}
+
+	opts = append([]Option{WithBaseURL(server.URL), WithHTTPClient(&http.Client{Transport: transport})}, opts...)
+	c, err := NewClient("domain.freshservice.com", "key", opts...)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return server, c
+}
+
+// closeConnections forces a new connection for every request, which is
+// how the client behaved before connections were reused
+var closeConnections = MiddlewareFunc(func(next RoundTripFunc) RoundTripFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		r = r.Clone(r.Context())
+		r.Close = true
+		return next(r)
+	}
+})
+
+func TestConnectionReuse(t *testing.T) {
+	var conns int64
+	server, c := newTLSBenchServer(t, &conns)
+	defer server.Close()
+
+	for i := 0; i < 10; i++ {
+		_, err := c.Tickets().Get(context.Background(), 1, nil)
+		assert.Nil(t, err)
+	}
+
+	assert.Equal(t, int64(1), atomic.LoadInt64(&conns))
+}
+
+func BenchmarkTicketGetKeepAlive(b *testing.B) {
+	var conns int64
+	server, c := newTLSBenchServer(b, &conns)
+	defer server.Close()
+
+	benchmarkTicketGet(b, c)
+	b.ReportMetric(float64(atomic.LoadInt64(&conns)), "conns")
+}
+
+func BenchmarkTicketGetCloseConnection(b *testing.B) {
+	var conns int64
+	server, c := newTLSBenchServer(b, &conns, WithMiddleware(closeConnections))
+	defer server.Close()
+
+	benchmarkTicketGet(b, c)
+	b.ReportMetric(float64(atomic.LoadInt64(&conns)), "conns")
+}
+
+func benchmarkTicketGet(b *testing.B, c *Client) {
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.Tickets().Get(ctx, 1, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -2,7 +2,16 @@ package freshservice_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/CoreyGriffin/go-freshservice/freshservice"
 	"github.com/stretchr/testify/assert"
@@ -39,3 +48,217 @@ func TestNewClientFailMissingAPIKey(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Equal(t, "A valid Freshservice API key is required to create a new API client", err.Error())
 }
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *freshservice.Client) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	c, err := freshservice.NewClient(domain, apiKey, freshservice.WithBaseURL(server.URL), freshservice.WithHTTPClient(server.Client()))
+	assert.Nil(t, err)
+
+	return server, c
+}
+
+func TestMakeRequestAPIError(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"description":"Validation failed","errors":[{"field":"email","message":"It should be a valid email address","code":"invalid_value"}]}`)
+	})
+	defer server.Close()
+
+	td, err := c.Tickets().Create(context.Background(), &freshservice.TicketDetails{})
+	assert.Nil(t, td)
+	assert.True(t, errors.Is(err, freshservice.ErrValidation))
+
+	var apiErr *freshservice.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, http.MethodPost, apiErr.Method)
+	assert.Equal(t, "Validation failed", apiErr.Description)
+	assert.Equal(t, []freshservice.Error{{Field: "email", Message: "It should be a valid email address", Code: "invalid_value"}}, apiErr.Errors)
+}
+
+func TestMakeRequestNotFound(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer server.Close()
+
+	_, err := c.Agents().Get(context.Background(), 1)
+	assert.True(t, errors.Is(err, freshservice.ErrNotFound))
+	assert.False(t, errors.Is(err, freshservice.ErrUnauthorized))
+}
+
+func TestMakeRequestNoContent(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer server.Close()
+
+	assert.Nil(t, c.Tasks().Delete(context.Background(), 1, 2))
+}
+
+func TestMakeRequestRetriesRateLimit(t *testing.T) {
+	var bodies []string
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"ticket":{"id":1,"subject":"retried"}}`)
+	})
+	defer server.Close()
+
+	c.RetryPolicy = freshservice.DefaultRetryPolicy()
+	c.RetryPolicy.RetryNonIdempotent = true
+
+	td, err := c.Tickets().Create(context.Background(), &freshservice.TicketDetails{Subject: "retried"})
+	assert.Nil(t, err)
+	assert.Equal(t, "retried", td.Subject)
+	assert.Len(t, bodies, 3)
+	assert.Equal(t, bodies[0], bodies[2])
+}
+
+func TestMakeRequestRetriesExhausted(t *testing.T) {
+	attempts := 0
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer server.Close()
+
+	c.RetryPolicy = &freshservice.RetryPolicy{MaxAttempts: 2}
+
+	_, err := c.Agents().Get(context.Background(), 1)
+	assert.True(t, errors.Is(err, freshservice.ErrRateLimited))
+	assert.Equal(t, 2, attempts)
+}
+
+func TestNewClientOptions(t *testing.T) {
+	var ua, path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ua = r.Header.Get("User-Agent")
+		path = r.URL.Path
+		fmt.Fprint(w, `{"agent":{"id":1}}`)
+	}))
+	defer server.Close()
+
+	httpClient := server.Client()
+	c, err := freshservice.NewClient(domain, apiKey,
+		freshservice.WithBaseURL(server.URL+"/proxy"),
+		freshservice.WithHTTPClient(httpClient),
+		freshservice.WithUserAgent("test-agent"),
+		freshservice.WithTimeout(time.Second),
+		freshservice.WithRetryPolicy(freshservice.DefaultRetryPolicy()),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, "domain.freshservice.com", c.Domain)
+	assert.NotNil(t, c.RetryPolicy)
+	assert.Equal(t, time.Duration(0), httpClient.Timeout)
+
+	ad, err := c.Agents().Get(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, ad.ID)
+	assert.Equal(t, "test-agent", ua)
+	assert.Equal(t, "/proxy/api/v2/agents/1", path)
+}
+
+func TestNewClientInvalidOptions(t *testing.T) {
+	_, err := freshservice.NewClient(domain, apiKey, freshservice.WithBaseURL("localhost:8080"))
+	assert.NotNil(t, err)
+
+	_, err = freshservice.NewClient(domain, apiKey, freshservice.WithHTTPClient(nil))
+	assert.NotNil(t, err)
+
+	_, err = freshservice.NewClient(domain, apiKey, freshservice.WithTimeout(0))
+	assert.NotNil(t, err)
+}
+
+func TestMakeRequestTimeout(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Agents().Get(ctx, 1)
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestMakeRequestDecodeError(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"agent":{"id":"not-a-number"`+strings.Repeat(" ", 500)+`}}`)
+	})
+	defer server.Close()
+
+	_, err := c.Agents().Get(context.Background(), 1)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `error decoding GET `)
+	assert.Contains(t, err.Error(), `{\"agent\":{\"id\":\"not-a-number\"`)
+	assert.True(t, strings.HasSuffix(err.Error(), `...")`))
+
+	var typeErr *json.UnmarshalTypeError
+	assert.True(t, errors.As(err, &typeErr))
+}
+
+func TestMakeRequestNonJSONError(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "<html>Bad Gateway</html>")
+	})
+	defer server.Close()
+
+	_, err := c.Tickets().Get(context.Background(), 1, nil)
+	var apiErr *freshservice.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "<html>Bad Gateway</html>", apiErr.Description)
+	assert.True(t, apiErr.Temporary())
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+type errCloser struct {
+	io.Reader
+}
+
+func (errCloser) Close() error { return errors.New("connection reset") }
+
+func TestMakeRequestCloseError(t *testing.T) {
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		status, body := http.StatusOK, `{"agent":{"id":1}}`
+		if r.Method == http.MethodDelete {
+			status, body = http.StatusNoContent, ""
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{},
+			Body:       errCloser{strings.NewReader(body)},
+			Request:    r,
+		}, nil
+	})
+
+	c, err := freshservice.NewClient(domain, apiKey, freshservice.WithHTTPClient(&http.Client{Transport: transport}))
+	assert.Nil(t, err)
+
+	// the close error is dropped once the response has been decoded
+	var agent *freshservice.AgentDetails
+	assert.NotPanics(t, func() {
+		agent, err = c.Agents().Get(context.Background(), 1)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, agent.ID)
+
+	err = c.Agents().Delete(context.Background(), 1)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "connection reset")
+}
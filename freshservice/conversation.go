@@ -0,0 +1,264 @@
+package freshservice
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const conversationURL = "/api/v2/conversations"
+
+/*
+NOTE: Conversations are listed, replied to and noted through the ticket endpoint
+but updated and deleted through the conversation endpoint
+*/
+
+// ConversationService is an interface for interacting with
+// the ticket conversation endpoints of the Freshservice API
+type ConversationService interface {
+	List(context.Context, int) ([]ConversationDetails, error)
+	ListWithResponse(context.Context, int) ([]ConversationDetails, *Response, error)
+	ListAll(context.Context, int) ([]ConversationDetails, error)
+	Iter(context.Context, int) *ConversationIterator
+	Reply(context.Context, int, *ReplyDetails) (*ConversationDetails, error)
+	ReplyWithResponse(context.Context, int, *ReplyDetails) (*ConversationDetails, *Response, error)
+	ReplyWithAttachment(context.Context, int, *ReplyDetails, []AttachmentFile, *UploadOptions) (*ConversationDetails, error)
+	ReplyWithAttachmentWithResponse(context.Context, int, *ReplyDetails, []AttachmentFile, *UploadOptions) (*ConversationDetails, *Response, error)
+	CreateNote(context.Context, int, *NoteDetails) (*ConversationDetails, error)
+	CreateNoteWithResponse(context.Context, int, *NoteDetails) (*ConversationDetails, *Response, error)
+	CreateNoteWithAttachment(context.Context, int, *NoteDetails, []AttachmentFile, *UploadOptions) (*ConversationDetails, error)
+	CreateNoteWithAttachmentWithResponse(context.Context, int, *NoteDetails, []AttachmentFile, *UploadOptions) (*ConversationDetails, *Response, error)
+	Update(context.Context, int, *ConversationUpdate) (*ConversationDetails, error)
+	UpdateWithResponse(context.Context, int, *ConversationUpdate) (*ConversationDetails, *Response, error)
+	UpdateWithAttachment(context.Context, int, *ConversationUpdate, []AttachmentFile, *UploadOptions) (*ConversationDetails, error)
+	UpdateWithAttachmentWithResponse(context.Context, int, *ConversationUpdate, []AttachmentFile, *UploadOptions) (*ConversationDetails, *Response, error)
+	Delete(context.Context, int) error
+	DeleteWithResponse(context.Context, int) (*Response, error)
+}
+
+// ConversationServiceClient facilitates requests with the ConversationService methods
+type ConversationServiceClient struct {
+	client *Client
+}
+
+// List the conversations on a given ticket ID
+func (c *ConversationServiceClient) List(ctx context.Context, tickID int) ([]ConversationDetails, error) {
+	list, _, err := c.ListWithResponse(ctx, tickID)
+	return list, err
+}
+
+// ListWithResponse is the same as List but also returns the Freshservice API response
+func (c *ConversationServiceClient) ListWithResponse(ctx context.Context, tickID int) ([]ConversationDetails, *Response, error) {
+	return c.list(ctx, tickID, nil)
+}
+
+// list requests a page of conversations for a given ticket ID
+func (c *ConversationServiceClient) list(ctx context.Context, tickID int, filter QueryFilter) ([]ConversationDetails, *Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%d/conversations", ticketURL, tickID), filter, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := &Conversations{}
+	resp, err := c.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return res.List, resp, nil
+}
+
+// Iter returns an iterator over every conversation on a given ticket ID,
+// following the pagination links returned by Freshservice
+func (c *ConversationServiceClient) Iter(ctx context.Context, tickID int) *ConversationIterator {
+	it := &ConversationIterator{}
+	it.pager = newPager(ctx, nil, func(ctx context.Context, f QueryFilter) (*Response, error) {
+		list, resp, err := c.list(ctx, tickID, f)
+		it.page = list
+		return resp, err
+	})
+	return it
+}
+
+// ListAll returns every conversation on a given ticket ID across all pages
+func (c *ConversationServiceClient) ListAll(ctx context.Context, tickID int) ([]ConversationDetails, error) {
+	var all []ConversationDetails
+	it := c.Iter(ctx, tickID)
+	for it.Next() {
+		all = append(all, it.Value())
+	}
+	return all, it.Err()
+}
+
+// ConversationIterator iterates over Freshservice ticket conversations page by page
+type ConversationIterator struct {
+	pager
+	page []ConversationDetails
+	cur  ConversationDetails
+}
+
+// Next advances the iterator to the next conversation. It returns
+// false when there are none left or an error occurred.
+func (it *ConversationIterator) Next() bool {
+	for len(it.page) == 0 {
+		if !it.fetchNext() {
+			return false
+		}
+	}
+
+	if !it.take() {
+		return false
+	}
+
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current conversation
+func (it *ConversationIterator) Value() ConversationDetails {
+	return it.cur
+}
+
+// Reply to a given ticket ID
+func (c *ConversationServiceClient) Reply(ctx context.Context, tickID int, rd *ReplyDetails) (*ConversationDetails, error) {
+	details, _, err := c.ReplyWithResponse(ctx, tickID, rd)
+	return details, err
+}
+
+// ReplyWithResponse is the same as Reply but also returns the Freshservice API response
+func (c *ConversationServiceClient) ReplyWithResponse(ctx context.Context, tickID int, rd *ReplyDetails) (*ConversationDetails, *Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%d/reply", ticketURL, tickID), nil, rd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.send(req)
+}
+
+// ReplyWithAttachment replies to a given ticket ID with attachments
+func (c *ConversationServiceClient) ReplyWithAttachment(ctx context.Context, tickID int, rd *ReplyDetails, files []AttachmentFile, opts *UploadOptions) (*ConversationDetails, error) {
+	details, _, err := c.ReplyWithAttachmentWithResponse(ctx, tickID, rd, files, opts)
+	return details, err
+}
+
+// ReplyWithAttachmentWithResponse is the same as ReplyWithAttachment but also returns the Freshservice API response
+func (c *ConversationServiceClient) ReplyWithAttachmentWithResponse(ctx context.Context, tickID int, rd *ReplyDetails, files []AttachmentFile, opts *UploadOptions) (*ConversationDetails, *Response, error) {
+	req, err := c.client.newMultipartRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%d/reply", ticketURL, tickID), rd, files, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.send(req)
+}
+
+// CreateNote adds a note to a given ticket ID. Set NotifyEmails to
+// notify agents of the note.
+func (c *ConversationServiceClient) CreateNote(ctx context.Context, tickID int, nd *NoteDetails) (*ConversationDetails, error) {
+	details, _, err := c.CreateNoteWithResponse(ctx, tickID, nd)
+	return details, err
+}
+
+// CreateNoteWithResponse is the same as CreateNote but also returns the Freshservice API response
+func (c *ConversationServiceClient) CreateNoteWithResponse(ctx context.Context, tickID int, nd *NoteDetails) (*ConversationDetails, *Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%d/notes", ticketURL, tickID), nil, nd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.send(req)
+}
+
+// CreateNoteWithAttachment adds a note with attachments to a given ticket ID
+func (c *ConversationServiceClient) CreateNoteWithAttachment(ctx context.Context, tickID int, nd *NoteDetails, files []AttachmentFile, opts *UploadOptions) (*ConversationDetails, error) {
+	details, _, err := c.CreateNoteWithAttachmentWithResponse(ctx, tickID, nd, files, opts)
+	return details, err
+}
+
+// CreateNoteWithAttachmentWithResponse is the same as CreateNoteWithAttachment but also returns the Freshservice API response
+func (c *ConversationServiceClient) CreateNoteWithAttachmentWithResponse(ctx context.Context, tickID int, nd *NoteDetails, files []AttachmentFile, opts *UploadOptions) (*ConversationDetails, *Response, error) {
+	path := fmt.Sprintf("%s/%d/notes", ticketURL, tickID)
+	fields, err := noteFormFields(nd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error encoding %s %s request body: %w", http.MethodPost, path, err)
+	}
+
+	req, err := c.client.newMultipartFormRequest(ctx, http.MethodPost, path, fields, files, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.send(req)
+}
+
+// noteFormFields encodes a note as form fields. Forms leave out false
+// booleans, so a note made public is sent with private set to false
+// explicitly rather than falling back to the private default.
+func noteFormFields(nd *NoteDetails) ([]formField, error) {
+	fields, err := formFields(nd)
+	if err != nil {
+		return nil, err
+	}
+	if nd != nil && nd.Private != nil && !*nd.Private {
+		fields = append(fields, formField{"private", "false"})
+	}
+	return fields, nil
+}
+
+// Update a conversation by its ID
+func (c *ConversationServiceClient) Update(ctx context.Context, id int, cu *ConversationUpdate) (*ConversationDetails, error) {
+	details, _, err := c.UpdateWithResponse(ctx, id, cu)
+	return details, err
+}
+
+// UpdateWithResponse is the same as Update but also returns the Freshservice API response
+func (c *ConversationServiceClient) UpdateWithResponse(ctx context.Context, id int, cu *ConversationUpdate) (*ConversationDetails, *Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%d", conversationURL, id), nil, cu)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.send(req)
+}
+
+// UpdateWithAttachment updates a conversation by its ID, adding attachments
+func (c *ConversationServiceClient) UpdateWithAttachment(ctx context.Context, id int, cu *ConversationUpdate, files []AttachmentFile, opts *UploadOptions) (*ConversationDetails, error) {
+	details, _, err := c.UpdateWithAttachmentWithResponse(ctx, id, cu, files, opts)
+	return details, err
+}
+
+// UpdateWithAttachmentWithResponse is the same as UpdateWithAttachment but also returns the Freshservice API response
+func (c *ConversationServiceClient) UpdateWithAttachmentWithResponse(ctx context.Context, id int, cu *ConversationUpdate, files []AttachmentFile, opts *UploadOptions) (*ConversationDetails, *Response, error) {
+	req, err := c.client.newMultipartRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%d", conversationURL, id), cu, files, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.send(req)
+}
+
+// Delete a conversation by its ID
+func (c *ConversationServiceClient) Delete(ctx context.Context, id int) error {
+	_, err := c.DeleteWithResponse(ctx, id)
+	return err
+}
+
+// DeleteWithResponse is the same as Delete but also returns the Freshservice API response
+func (c *ConversationServiceClient) DeleteWithResponse(ctx context.Context, id int) (*Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", conversationURL, id), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.makeRequest(req, nil)
+}
+
+// send makes a request returning a single conversation
+func (c *ConversationServiceClient) send(req *http.Request) (*ConversationDetails, *Response, error) {
+	res := &Conversation{}
+	resp, err := c.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &res.Details, resp, nil
+}
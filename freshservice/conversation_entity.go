@@ -0,0 +1,58 @@
+package freshservice
+
+import "time"
+
+// Conversations holds a list of conversations on a Freshservice ticket
+type Conversations struct {
+	List []ConversationDetails `json:"conversations"`
+}
+
+// Conversation holds the details of a specific ticket conversation
+type Conversation struct {
+	Details ConversationDetails `json:"conversation"`
+}
+
+// ConversationDetails are the details of a reply or note on a Freshservice ticket
+type ConversationDetails struct {
+	ID           int          `json:"id"`
+	TicketID     int          `json:"ticket_id"`
+	UserID       int          `json:"user_id"`
+	Source       int          `json:"source"`
+	Incoming     bool         `json:"incoming"`
+	Private      bool         `json:"private"`
+	Body         string       `json:"body"`
+	BodyText     string       `json:"body_text"`
+	FromEmail    string       `json:"from_email"`
+	ToEmails     []string     `json:"to_emails"`
+	CcEmails     []string     `json:"cc_emails"`
+	BccEmails    []string     `json:"bcc_emails"`
+	SupportEmail string       `json:"support_email"`
+	Attachments  []Attachment `json:"attachments"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// ReplyDetails holds the content of a reply to a Freshservice ticket
+type ReplyDetails struct {
+	Body      string   `json:"body"` // Mandatory, HTML content of the reply
+	FromEmail string   `json:"from_email,omitempty"`
+	UserID    int      `json:"user_id,omitempty"`
+	CcEmails  []string `json:"cc_emails,omitempty"`
+	BccEmails []string `json:"bcc_emails,omitempty"`
+}
+
+// NoteDetails holds the content of a note added to a Freshservice ticket.
+// Notes are private unless Private is set to false, which makes the note
+// visible to the requester.
+type NoteDetails struct {
+	Body         string   `json:"body"` // Mandatory, HTML content of the note
+	Private      *bool    `json:"private,omitempty"`
+	Incoming     bool     `json:"incoming"`
+	NotifyEmails []string `json:"notify_emails,omitempty"`
+	UserID       int      `json:"user_id,omitempty"`
+}
+
+// ConversationUpdate holds the changes made to an existing ticket conversation
+type ConversationUpdate struct {
+	Body string `json:"body,omitempty"`
+}
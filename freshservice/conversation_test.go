@@ -0,0 +1,145 @@
+package freshservice_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/CoreyGriffin/go-freshservice/freshservice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConversationList(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/tickets/7/conversations", r.URL.Path)
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, r.URL.Path))
+			fmt.Fprint(w, `{"conversations":[{"id":1,"ticket_id":7,"private":true}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"conversations":[{"id":2,"ticket_id":7,"body_text":"Thanks"}]}`)
+	})
+	defer server.Close()
+
+	list, err := c.Conversations().List(context.Background(), 7)
+	assert.Nil(t, err)
+	assert.Len(t, list, 1)
+	assert.True(t, list[0].Private)
+
+	all, err := c.Conversations().ListAll(context.Background(), 7)
+	assert.Nil(t, err)
+	assert.Len(t, all, 2)
+	assert.Equal(t, "Thanks", all[1].BodyText)
+}
+
+func TestConversationCreateNote(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v2/tickets/7/notes", r.URL.Path)
+
+		var body map[string]interface{}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{
+			"body":          "<pre>disk full</pre>",
+			"private":       true,
+			"incoming":      false,
+			"notify_emails": []interface{}{"oncall@example.com"},
+		}, body)
+
+		fmt.Fprint(w, `{"conversation":{"id":3,"ticket_id":7,"private":true,"body":"<pre>disk full</pre>"}}`)
+	})
+	defer server.Close()
+
+	note, err := c.Conversations().CreateNote(context.Background(), 7, &freshservice.NoteDetails{
+		Body:         "<pre>disk full</pre>",
+		Private:      freshservice.Bool(true),
+		NotifyEmails: []string{"oncall@example.com"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, note.ID)
+	assert.True(t, note.Private)
+}
+
+func TestConversationCreateNoteDefaultPrivate(t *testing.T) {
+	var body map[string]interface{}
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		fmt.Fprint(w, `{"conversation":{"id":3}}`)
+	})
+	defer server.Close()
+
+	// private is left to the Freshservice default unless it is set
+	_, err := c.Conversations().CreateNote(context.Background(), 7, &freshservice.NoteDetails{Body: "Rebooted"})
+	assert.Nil(t, err)
+	assert.NotContains(t, body, "private")
+
+	_, err = c.Conversations().CreateNote(context.Background(), 7, &freshservice.NoteDetails{Body: "Fixed", Private: freshservice.Bool(false)})
+	assert.Nil(t, err)
+	assert.Equal(t, false, body["private"])
+}
+
+func TestConversationCreateNoteWithAttachment(t *testing.T) {
+	var private []string
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/tickets/7/notes", r.URL.Path)
+		assert.Nil(t, r.ParseMultipartForm(1<<20))
+		private = r.MultipartForm.Value["private"]
+		assert.Equal(t, []string{"oncall@example.com"}, r.MultipartForm.Value["notify_emails[]"])
+		assert.Len(t, r.MultipartForm.File["attachments[]"], 1)
+
+		fmt.Fprint(w, `{"conversation":{"id":4,"attachments":[{"name":"diag.txt"}]}}`)
+	})
+	defer server.Close()
+
+	files := []freshservice.AttachmentFile{{Name: "diag.txt", ContentType: "text/plain", Reader: strings.NewReader("uptime 3d")}}
+	note, err := c.Conversations().CreateNoteWithAttachment(context.Background(), 7, &freshservice.NoteDetails{
+		Body:         "Diagnostics attached",
+		NotifyEmails: []string{"oncall@example.com"},
+	}, files, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "diag.txt", note.Attachments[0].Name)
+	assert.Nil(t, private)
+
+	// a public note sends private even though forms leave out false booleans
+	files[0].Reader = strings.NewReader("uptime 3d")
+	_, err = c.Conversations().CreateNoteWithAttachment(context.Background(), 7, &freshservice.NoteDetails{
+		Body:         "Diagnostics attached",
+		Private:      freshservice.Bool(false),
+		NotifyEmails: []string{"oncall@example.com"},
+	}, files, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"false"}, private)
+}
+
+func TestConversationReplyUpdateDelete(t *testing.T) {
+	var requests []string
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		fmt.Fprint(w, `{"conversation":{"id":5}}`)
+	})
+	defer server.Close()
+
+	ctx := context.Background()
+	reply, err := c.Conversations().Reply(ctx, 7, &freshservice.ReplyDetails{Body: "On it", CcEmails: []string{"a@example.com"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 5, reply.ID)
+
+	_, err = c.Conversations().Update(ctx, 5, &freshservice.ConversationUpdate{Body: "On it now"})
+	assert.Nil(t, err)
+
+	assert.Nil(t, c.Conversations().Delete(ctx, 5))
+
+	assert.Equal(t, []string{
+		"POST /api/v2/tickets/7/reply",
+		"PUT /api/v2/conversations/5",
+		"DELETE /api/v2/conversations/5",
+	}, requests)
+}
@@ -0,0 +1,204 @@
+package freshservice
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Plan is the number of API credits per minute granted
+// by a Freshservice plan tier
+type Plan int
+
+const (
+	// PlanStarter allows 100 API credits per minute
+	PlanStarter Plan = 100
+	// PlanGrowth allows 200 API credits per minute
+	PlanGrowth Plan = 200
+	// PlanPro allows 400 API credits per minute
+	PlanPro Plan = 400
+	// PlanEnterprise allows 500 API credits per minute
+	PlanEnterprise Plan = 500
+)
+
+const (
+	// baseRequestCredits is the cost of any API call
+	baseRequestCredits = 1
+	// embedCredits is the additional cost of each embedded include
+	embedCredits = 2
+)
+
+// CreditLimiter is a token bucket that budgets the API credits spent by
+// one or more clients sharing an API key. Each request is charged 1
+// credit plus 2 credits for every embedded include and the bucket is
+// refilled continuously at the per minute rate of the plan. The same
+// limiter can be assigned to several clients.
+type CreditLimiter struct {
+	// FailFast returns ErrCreditBudgetExhausted instead of
+	// waiting for credits to become available
+	FailFast bool
+
+	mu       sync.Mutex
+	capacity float64
+	credits  float64
+	rate     float64 // credits per second
+	last     time.Time
+	now      func() time.Time
+
+	// credits charged for requests that have not been synced yet
+	inFlight float64
+	pending  int
+}
+
+// NewCreditLimiter returns a full credit limiter sized for the given plan
+func NewCreditLimiter(plan Plan) *CreditLimiter {
+	capacity := float64(plan)
+	if capacity <= 0 {
+		capacity = float64(PlanStarter)
+	}
+
+	return &CreditLimiter{
+		capacity: capacity,
+		credits:  capacity,
+		rate:     capacity / time.Minute.Seconds(),
+		last:     time.Now(),
+		now:      time.Now,
+	}
+}
+
+// Available returns the number of whole credits that can currently be spent
+func (l *CreditLimiter) Available() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+	return int(l.credits)
+}
+
+// Wait blocks until the requested number of credits are available and
+// spends them. It returns early if the context is done, or immediately
+// with ErrCreditBudgetExhausted when the limiter is set to fail fast.
+func (l *CreditLimiter) Wait(ctx context.Context, cost int) error {
+	for {
+		l.mu.Lock()
+		l.refill()
+
+		need := float64(cost)
+		if need > l.capacity {
+			need = l.capacity
+		}
+
+		if l.credits >= need {
+			l.credits -= need
+			l.inFlight += need
+			l.pending++
+			l.mu.Unlock()
+			return nil
+		}
+
+		if l.FailFast {
+			l.mu.Unlock()
+			return ErrCreditBudgetExhausted
+		}
+
+		wait := time.Duration((need - l.credits) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// Sync updates the limiter from the rate limit headers returned by
+// Freshservice so that credits spent by other consumers of the same
+// API key are accounted for. The remaining credits reported include the
+// X-Ratelimit-Used-Currentrequest credits of the request that returned
+// them but not those of other requests still in flight, which stay charged.
+func (l *CreditLimiter) Sync(h http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.pending > 0 {
+		used, err := strconv.Atoi(h.Get("X-Ratelimit-Used-Currentrequest"))
+		if err != nil {
+			used = baseRequestCredits
+		}
+		l.settle(float64(used))
+	}
+
+	remaining, err := strconv.Atoi(h.Get("X-Ratelimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	if total, err := strconv.Atoi(h.Get("X-Ratelimit-Total")); err == nil && total > 0 {
+		l.capacity = float64(total)
+		l.rate = l.capacity / time.Minute.Seconds()
+	}
+
+	l.refill()
+	l.credits = float64(remaining) - l.inFlight
+	if l.credits > l.capacity {
+		l.credits = l.capacity
+	}
+	if l.credits < 0 {
+		l.credits = 0
+	}
+}
+
+// release settles the charge of a request that failed without a
+// response to sync from, the credits it was charged stay spent
+func (l *CreditLimiter) release(cost int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.pending > 0 {
+		l.settle(float64(cost))
+	}
+}
+
+// settle removes a finished request from the in flight charges, it must
+// be called with the lock held. The charges are cleared once no request
+// is left in flight so that a mismatched cost cannot accumulate.
+func (l *CreditLimiter) settle(cost float64) {
+	l.pending--
+	l.inFlight -= cost
+	if l.pending == 0 || l.inFlight < 0 {
+		l.inFlight = 0
+	}
+}
+
+// refill adds the credits accrued since the last call, it must be
+// called with the lock held
+func (l *CreditLimiter) refill() {
+	now := l.now()
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+
+	if elapsed <= 0 {
+		return
+	}
+
+	l.credits += elapsed * l.rate
+	if l.credits > l.capacity {
+		l.credits = l.capacity
+	}
+}
+
+// requestCredits returns the number of API credits a request will
+// consume based on the number of embeds it includes
+func requestCredits(r *http.Request) int {
+	credits := baseRequestCredits
+	for _, include := range r.URL.Query()["include"] {
+		for _, embed := range strings.Split(include, ",") {
+			if strings.TrimSpace(embed) != "" {
+				credits += embedCredits
+			}
+		}
+	}
+	return credits
+}
@@ -0,0 +1,90 @@
+package freshservice
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestCredits(t *testing.T) {
+	cases := []struct {
+		URL      string
+		Expected int
+	}{
+		{URL: "https://domain.freshservice.com/api/v2/tickets", Expected: 1},
+		{URL: "https://domain.freshservice.com/api/v2/tickets?include=stats", Expected: 3},
+		{URL: "https://domain.freshservice.com/api/v2/tickets?include=stats,requester", Expected: 5},
+		{URL: "https://domain.freshservice.com/api/v2/tickets?include=stats&include=requester", Expected: 5},
+	}
+
+	for _, c := range cases {
+		r, _ := http.NewRequest(http.MethodGet, c.URL, nil)
+		assert.Equal(t, c.Expected, requestCredits(r), c.URL)
+	}
+}
+
+func TestCreditLimiterFailFast(t *testing.T) {
+	now := time.Now()
+	l := NewCreditLimiter(PlanStarter)
+	l.now = func() time.Time { return now }
+	l.FailFast = true
+
+	assert.Nil(t, l.Wait(context.Background(), 99))
+	assert.Nil(t, l.Wait(context.Background(), 1))
+	assert.Equal(t, ErrCreditBudgetExhausted, l.Wait(context.Background(), 1))
+
+	// 100 credits per minute refills 1 credit every 600ms
+	now = now.Add(600 * time.Millisecond)
+	assert.Nil(t, l.Wait(context.Background(), 1))
+}
+
+func TestCreditLimiterWaitContext(t *testing.T) {
+	l := NewCreditLimiter(PlanStarter)
+	assert.Nil(t, l.Wait(context.Background(), 100))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, l.Wait(ctx, 50))
+}
+
+func TestCreditLimiterSync(t *testing.T) {
+	l := NewCreditLimiter(PlanStarter)
+	l.Sync(http.Header{
+		"X-Ratelimit-Total":     []string{"400"},
+		"X-Ratelimit-Remaining": []string{"12"},
+	})
+	assert.Equal(t, 12, l.Available())
+	assert.Equal(t, float64(400), l.capacity)
+}
+
+func TestCreditLimiterSyncInFlight(t *testing.T) {
+	now := time.Now()
+	l := NewCreditLimiter(PlanStarter)
+	l.now = func() time.Time { return now }
+
+	assert.Nil(t, l.Wait(context.Background(), 5))
+	assert.Nil(t, l.Wait(context.Background(), 3))
+
+	// the second request is still in flight when the first returns
+	l.Sync(http.Header{
+		"X-Ratelimit-Remaining":           []string{"95"},
+		"X-Ratelimit-Used-Currentrequest": []string{"5"},
+	})
+	assert.Equal(t, 92, l.Available())
+
+	l.Sync(http.Header{
+		"X-Ratelimit-Remaining":           []string{"92"},
+		"X-Ratelimit-Used-Currentrequest": []string{"3"},
+	})
+	assert.Equal(t, 92, l.Available())
+	assert.Equal(t, float64(0), l.inFlight)
+
+	// a failed request is no longer in flight but its credits stay spent
+	assert.Nil(t, l.Wait(context.Background(), 2))
+	l.release(2)
+	assert.Equal(t, 90, l.Available())
+	assert.Equal(t, 0, l.pending)
+}
@@ -0,0 +1,190 @@
+package freshservice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxDownloadRedirects is the number of redirects followed
+// when downloading an attachment
+const maxDownloadRedirects = 10
+
+// DownloadAttachment streams the content of a ticket or conversation
+// attachment to w. The API key is only sent to the Freshservice domain,
+// never to the storage host Freshservice redirects to. The content type
+// and size are checked against the attachment, returning
+// ErrAttachmentMismatch if they differ. Requests that fail before any
+// content is received are retried with the client's RetryPolicy, or
+// DefaultRetryPolicy if it has none.
+func (fs *Client) DownloadAttachment(ctx context.Context, a Attachment, w io.Writer) error {
+	res, err := fs.openAttachment(ctx, a)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if err := checkAttachmentType(a, res); err != nil {
+		return err
+	}
+
+	if a.Size > 0 && res.ContentLength >= 0 && res.ContentLength != int64(a.Size) {
+		return fmt.Errorf("error downloading attachment %q: %d bytes expected, %d bytes reported: %w", a.Name, a.Size, res.ContentLength, ErrAttachmentMismatch)
+	}
+
+	body := io.Reader(res.Body)
+	if a.Size > 0 {
+		// read one byte past the expected size to detect a larger body
+		body = io.LimitReader(res.Body, int64(a.Size)+1)
+	}
+
+	n, err := io.Copy(w, body)
+	if err != nil {
+		return fmt.Errorf("error downloading attachment %q: %w", a.Name, err)
+	}
+
+	if a.Size > 0 && n != int64(a.Size) {
+		return fmt.Errorf("error downloading attachment %q: %d bytes expected, %d bytes received: %w", a.Name, a.Size, n, ErrAttachmentMismatch)
+	}
+
+	return nil
+}
+
+// SaveAttachment downloads an attachment into dir, returning the path
+// of the file. The file is named after the attachment, replacing any
+// file with the same name, and is only created once the whole
+// attachment has been downloaded and verified.
+func (fs *Client) SaveAttachment(ctx context.Context, a Attachment, dir string) (string, error) {
+	path := filepath.Join(dir, attachmentFileName(a.Name))
+
+	tmp, err := ioutil.TempFile(dir, ".download-*")
+	if err != nil {
+		return "", fmt.Errorf("error saving attachment %q: %w", a.Name, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := fs.DownloadAttachment(ctx, a, tmp); err != nil {
+		tmp.Close()
+		return "", err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("error saving attachment %q: %w", a.Name, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("error saving attachment %q: %w", a.Name, err)
+	}
+
+	return path, nil
+}
+
+// openAttachment requests the content of an attachment, following
+// redirects itself so credentials are only sent to the API host
+func (fs *Client) openAttachment(ctx context.Context, a Attachment) (*http.Response, error) {
+	if a.AttachmentURL == "" {
+		return nil, fmt.Errorf("error downloading attachment %q: no attachment URL", a.Name)
+	}
+
+	target, err := fs.baseURL.Parse(a.AttachmentURL)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading attachment %q: %w", a.Name, err)
+	}
+
+	policy := fs.RetryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+
+	// redirects are returned rather than followed by the HTTP client
+	client := *fs.client
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	for redirects := 0; ; redirects++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", fs.userAgent)
+		if fs.isAPIHost(target) {
+			req.SetBasicAuth(fs.Auth.APIKey, "x")
+		}
+
+		res, err := fs.doWith(&client, policy, req)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isRedirect(res.StatusCode) {
+			if res.StatusCode < http.StatusOK || res.StatusCode > 299 {
+				defer discardBody(res.Body)
+				return nil, newAPIError(req, res)
+			}
+			return res, nil
+		}
+
+		location := res.Header.Get("Location")
+		discardBody(res.Body)
+
+		if location == "" {
+			return nil, fmt.Errorf("error downloading attachment %q: redirect without a location", a.Name)
+		}
+		if redirects == maxDownloadRedirects {
+			return nil, fmt.Errorf("error downloading attachment %q: stopped after %d redirects", a.Name, maxDownloadRedirects)
+		}
+
+		if target, err = target.Parse(location); err != nil {
+			return nil, fmt.Errorf("error downloading attachment %q: %w", a.Name, err)
+		}
+	}
+}
+
+// checkAttachmentType compares the media type of the response with the
+// content type of the attachment. Storage hosts commonly serve files as
+// application/octet-stream so that is always accepted.
+func checkAttachmentType(a Attachment, res *http.Response) error {
+	if a.ContentType == "" {
+		return nil
+	}
+
+	got, _, err := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	if err != nil || got == "application/octet-stream" {
+		return nil
+	}
+
+	want, _, err := mime.ParseMediaType(a.ContentType)
+	if err != nil {
+		return nil
+	}
+
+	if !strings.EqualFold(got, want) {
+		return fmt.Errorf("error downloading attachment %q: content type %q expected, %q received: %w", a.Name, want, got, ErrAttachmentMismatch)
+	}
+	return nil
+}
+
+// attachmentFileName returns a file name for an attachment that
+// cannot escape the directory it is saved in
+func attachmentFileName(name string) string {
+	name = filepath.Base(filepath.Clean("/" + strings.ReplaceAll(name, `\`, "/")))
+	if name == "" || name == "." || name == ".." || name == string(filepath.Separator) {
+		return "attachment"
+	}
+	return name
+}
+
+func isRedirect(code int) bool {
+	switch code {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package freshservice_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CoreyGriffin/go-freshservice/freshservice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadAttachment(t *testing.T) {
+	content := []byte("%PDF-1.4 report")
+
+	var storageRequests int
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		storageRequests++
+		assert.Empty(t, r.Header.Get("Authorization"))
+		assert.Empty(t, r.Header.Get("X-Proxy-Token"))
+
+		if storageRequests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf; charset=binary")
+		w.Write(content)
+	}))
+	defer storage.Close()
+
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "secret", r.Header.Get("X-Proxy-Token"))
+		assert.Equal(t, "/helpdesk/attachments/1", r.URL.Path)
+		http.Redirect(w, r, storage.URL+"/bucket/report.pdf?signature=abc", http.StatusFound)
+	})
+	defer server.Close()
+
+	c.RetryPolicy = &freshservice.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}
+
+	// middleware and metrics only apply to the API host, not the storage host
+	metrics := freshservice.NewMetrics()
+	c.Use(freshservice.HeaderMiddleware(http.Header{"X-Proxy-Token": {"secret"}}), metrics)
+
+	a := freshservice.Attachment{
+		Name:          "report.pdf",
+		ContentType:   "application/pdf",
+		Size:          len(content),
+		AttachmentURL: server.URL + "/helpdesk/attachments/1",
+	}
+
+	var buf bytes.Buffer
+	assert.Nil(t, c.DownloadAttachment(context.Background(), a, &buf))
+	assert.Equal(t, content, buf.Bytes())
+	assert.Equal(t, 2, storageRequests)
+
+	stats := metrics.Snapshot()
+	assert.Len(t, stats, 1)
+	assert.Equal(t, "/helpdesk/attachments/{id}", stats[0].Route)
+}
+
+func TestDownloadAttachmentMismatch(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/html" {
+			w.Header().Set("Content-Type", "text/html")
+		}
+		// no content length, the size is checked while streaming
+		w.(http.Flusher).Flush()
+		fmt.Fprint(w, "0123456789")
+	})
+	defer server.Close()
+
+	var buf bytes.Buffer
+	err := c.DownloadAttachment(context.Background(), freshservice.Attachment{Name: "a.bin", Size: 4, AttachmentURL: server.URL + "/bin"}, &buf)
+	assert.True(t, errors.Is(err, freshservice.ErrAttachmentMismatch))
+
+	err = c.DownloadAttachment(context.Background(), freshservice.Attachment{Name: "a.png", ContentType: "image/png", AttachmentURL: server.URL + "/html"}, &buf)
+	assert.True(t, errors.Is(err, freshservice.ErrAttachmentMismatch))
+
+	err = c.DownloadAttachment(context.Background(), freshservice.Attachment{Name: "none"}, &buf)
+	assert.NotNil(t, err)
+}
+
+func TestSaveAttachment(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "root:x:0:0")
+	})
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "attachments")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path, err := c.SaveAttachment(context.Background(), freshservice.Attachment{Name: "../../etc/passwd", AttachmentURL: server.URL + "/passwd"}, dir)
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, "passwd"), path)
+
+	b, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "root:x:0:0", string(b))
+
+	_, err = c.SaveAttachment(context.Background(), freshservice.Attachment{Name: "gone.txt", AttachmentURL: server.URL + "/missing"}, dir)
+	assert.True(t, errors.Is(err, freshservice.ErrNotFound))
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	assert.Equal(t, []string{path}, files)
+}
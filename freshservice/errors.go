@@ -3,6 +3,37 @@ package freshservice
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrNotFound is matched by an APIError for a HTTP 404 response
+	ErrNotFound = errors.New("freshservice: resource not found")
+	// ErrUnauthorized is matched by an APIError for a HTTP 401 or 403 response
+	ErrUnauthorized = errors.New("freshservice: unauthorized")
+	// ErrRateLimited is matched by an APIError for a HTTP 429 response
+	ErrRateLimited = errors.New("freshservice: rate limit exceeded")
+	// ErrValidation is matched by an APIError for a HTTP 400 or 422 response
+	ErrValidation = errors.New("freshservice: validation failed")
+	// ErrCreditBudgetExhausted is returned by a fail fast CreditLimiter
+	// when there are not enough API credits left to make a request
+	ErrCreditBudgetExhausted = errors.New("freshservice: API credit budget exhausted")
+	// ErrCheckpointMismatch is returned when resuming a list traversal
+	// with a filter that differs from the one saved in its checkpoint
+	ErrCheckpointMismatch = errors.New("freshservice: filter does not match checkpoint")
+	// ErrAttachmentTooLarge is returned when the attachments uploaded
+	// with a request exceed the total size limit
+	ErrAttachmentTooLarge = errors.New("freshservice: attachments exceed the total size limit")
+	// ErrAttachmentMismatch is returned when a downloaded attachment does
+	// not have the size or content type of its metadata
+	ErrAttachmentMismatch = errors.New("freshservice: downloaded attachment does not match its metadata")
+	// ErrInvalidQuery is returned for a ticket filter query that
+	// Freshservice would reject
+	ErrInvalidQuery = errors.New("freshservice: invalid filter query")
+	// ErrTimerPosted is returned when stopping a time entry timer
+	// whose time spent was already logged or is being logged
+	ErrTimerPosted = errors.New("freshservice: timer was already logged as a time entry")
 )
 
 // ErrorResponse represents a Freshservice API error
@@ -18,6 +49,60 @@ type Error struct {
 	Code    string `json:"code"`
 }
 
+// APIError is returned for any non 2xx response from the Freshservice API.
+// It can be compared against the Err* sentinel values with errors.Is
+// or unwrapped with errors.As to inspect the field level errors.
+type APIError struct {
+	StatusCode  int
+	Method      string
+	URL         string
+	Description string
+	Errors      []Error
+	RequestID   string
+}
+
+// Error satisfies the error interface
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s: %d %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Description != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Description)
+	}
+
+	var fields []string
+	for _, fe := range e.Errors {
+		if fe.Field != "" {
+			fields = append(fields, fmt.Sprintf("%s: %s", fe.Field, fe.Message))
+			continue
+		}
+		fields = append(fields, fe.Message)
+	}
+	if len(fields) > 0 {
+		msg = fmt.Sprintf("%s (%s)", msg, strings.Join(fields, "; "))
+	}
+
+	return msg
+}
+
+// Is allows an APIError to be matched against the package sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	}
+	return false
+}
+
+// Temporary reports whether the request may succeed if retried later
+func (e *APIError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
 // Helper to be used for API client config errors
 func missingClientConfigErr(attr string) error {
 	errTxt := fmt.Sprintf("A valid Freshservice %s is required to create a new API client", attr)
@@ -1,6 +1,8 @@
 package freshservice
 
 import (
+	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -31,3 +33,39 @@ func TestMissingClientConfigErr(t *testing.T) {
 		assert.Equal(t, c.Expected, err.Error())
 	}
 }
+
+func TestAPIErrorIs(t *testing.T) {
+	cases := []struct {
+		StatusCode int
+		Target     error
+		Expected   bool
+	}{
+		{StatusCode: http.StatusNotFound, Target: ErrNotFound, Expected: true},
+		{StatusCode: http.StatusUnauthorized, Target: ErrUnauthorized, Expected: true},
+		{StatusCode: http.StatusForbidden, Target: ErrUnauthorized, Expected: true},
+		{StatusCode: http.StatusTooManyRequests, Target: ErrRateLimited, Expected: true},
+		{StatusCode: http.StatusBadRequest, Target: ErrValidation, Expected: true},
+		{StatusCode: http.StatusInternalServerError, Target: ErrValidation, Expected: false},
+		{StatusCode: http.StatusBadRequest, Target: ErrNotFound, Expected: false},
+	}
+
+	for _, c := range cases {
+		var err error = &APIError{StatusCode: c.StatusCode}
+		assert.Equal(t, c.Expected, errors.Is(err, c.Target), "status %d", c.StatusCode)
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	err := &APIError{
+		StatusCode:  http.StatusBadRequest,
+		Method:      http.MethodPost,
+		URL:         "https://domain.freshservice.com/api/v2/tickets",
+		Description: "Validation failed",
+		Errors: []Error{
+			{Field: "email", Message: "It should be a valid email address", Code: "invalid_value"},
+		},
+	}
+
+	assert.Equal(t, "POST https://domain.freshservice.com/api/v2/tickets: 400 Bad Request: Validation failed (email: It should be a valid email address)", err.Error())
+	assert.False(t, err.Temporary())
+}
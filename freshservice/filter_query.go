@@ -0,0 +1,273 @@
+package freshservice
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxFilterQueryLength is the longest ticket filter query, excluding the
+// enclosing quotes, accepted by Freshservice
+const MaxFilterQueryLength = 512
+
+// filterFieldKind is the type of value a filter field is compared with
+type filterFieldKind int
+
+const (
+	numberField filterFieldKind = iota
+	textField
+	dateField
+	customField
+)
+
+// ticketFilterFields are the standard ticket fields the filter endpoint
+// supports. Any other field name is treated as a custom field.
+var ticketFilterFields = map[string]filterFieldKind{
+	"agent_id":      numberField,
+	"group_id":      numberField,
+	"requester_id":  numberField,
+	"department_id": numberField,
+	"workspace_id":  numberField,
+	"priority":      numberField,
+	"status":        numberField,
+	"impact":        numberField,
+	"urgency":       numberField,
+	"source":        numberField,
+	"type":          textField,
+	"tag":           textField,
+	"category":      textField,
+	"sub_category":  textField,
+	"item_category": textField,
+	"created_at":    dateField,
+	"updated_at":    dateField,
+	"due_by":        dateField,
+	"fr_due_by":     dateField,
+}
+
+var filterFieldName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Q builds ticket filter queries, for example
+//
+//	Q.Eq("priority", HighPriority).And(Q.Gt("created_at", since)).Or(Q.Eq("agent_id", nil))
+var Q QueryBuilder
+
+// QueryBuilder creates the conditions of a ticket filter query
+type QueryBuilder struct{}
+
+// Query is a ticket filter query. Queries are immutable, combining them
+// returns a new query. Invalid conditions are reported by Validate.
+type Query struct {
+	op       string // AND, OR or empty for a single condition
+	cond     string
+	children []*Query
+	err      error
+}
+
+// Eq matches tickets where the field equals the value. A nil value
+// matches tickets where the field is not set.
+func (QueryBuilder) Eq(field string, value interface{}) *Query {
+	return newCondition(field, ":", value)
+}
+
+// Gt matches tickets where the field is greater than or equal to the
+// value, Freshservice compares dates and numbers inclusively
+func (QueryBuilder) Gt(field string, value interface{}) *Query {
+	return newCondition(field, ":>", value)
+}
+
+// Lt matches tickets where the field is less than or equal to the
+// value, Freshservice compares dates and numbers inclusively
+func (QueryBuilder) Lt(field string, value interface{}) *Query {
+	return newCondition(field, ":<", value)
+}
+
+// And matches tickets matching every query
+func (QueryBuilder) And(queries ...*Query) *Query {
+	return combine("AND", queries)
+}
+
+// Or matches tickets matching any of the queries
+func (QueryBuilder) Or(queries ...*Query) *Query {
+	return combine("OR", queries)
+}
+
+// And matches tickets matching the query and every other query
+func (q *Query) And(others ...*Query) *Query {
+	return combine("AND", append([]*Query{q}, others...))
+}
+
+// Or matches tickets matching the query or any of the other queries
+func (q *Query) Or(others ...*Query) *Query {
+	return combine("OR", append([]*Query{q}, others...))
+}
+
+// String renders the query in the Freshservice filter syntax,
+// without the enclosing double quotes
+func (q *Query) String() string {
+	if q == nil {
+		return ""
+	}
+	if q.op == "" {
+		return q.cond
+	}
+
+	parts := make([]string, len(q.children))
+	for i, c := range q.children {
+		parts[i] = c.String()
+		// nested groups are always parenthesised rather than relying on precedence
+		if c.op != "" && c.op != q.op {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, " "+q.op+" ")
+}
+
+// Validate reports the first invalid condition of the query, or a query
+// that is longer than MaxFilterQueryLength. The errors match ErrInvalidQuery.
+func (q *Query) Validate() error {
+	if q == nil {
+		return fmt.Errorf("%w: empty query", ErrInvalidQuery)
+	}
+	if err := q.firstErr(); err != nil {
+		return err
+	}
+	if n := len(q.String()); n > MaxFilterQueryLength {
+		return fmt.Errorf("%w: %d characters is longer than the limit of %d", ErrInvalidQuery, n, MaxFilterQueryLength)
+	}
+	return nil
+}
+
+func (q *Query) firstErr() error {
+	if q.err != nil {
+		return q.err
+	}
+	for _, c := range q.children {
+		if err := c.firstErr(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// combine joins queries with a boolean operator, flattening nested
+// queries using the same operator
+func combine(op string, queries []*Query) *Query {
+	q := &Query{op: op}
+	for _, c := range queries {
+		switch {
+		case c == nil:
+			q.err = fmt.Errorf("%w: nil query in %s", ErrInvalidQuery, op)
+		case c.op == op:
+			q.children = append(q.children, c.children...)
+			if c.err != nil {
+				q.err = c.err
+			}
+		default:
+			q.children = append(q.children, c)
+		}
+	}
+
+	if len(q.children) == 0 && q.err == nil {
+		q.err = fmt.Errorf("%w: %s without any query", ErrInvalidQuery, op)
+	}
+	if len(q.children) == 1 && q.err == nil {
+		return q.children[0]
+	}
+	return q
+}
+
+// newCondition validates and renders a single field condition
+func newCondition(field string, operator string, value interface{}) *Query {
+	q := &Query{}
+
+	if !filterFieldName.MatchString(field) {
+		q.err = fmt.Errorf("%w: invalid field name %q", ErrInvalidQuery, field)
+		return q
+	}
+
+	kind, ok := ticketFilterFields[field]
+	if !ok {
+		kind = customField
+	}
+
+	if operator != ":" && kind == textField {
+		q.err = fmt.Errorf("%w: field %q can only be compared for equality", ErrInvalidQuery, field)
+		return q
+	}
+
+	rendered, err := renderFilterValue(kind, value)
+	if err != nil {
+		q.err = fmt.Errorf("%w: field %q: %v", ErrInvalidQuery, field, err)
+		return q
+	}
+
+	if rendered == "null" && operator != ":" {
+		q.err = fmt.Errorf("%w: field %q can only be compared with null for equality", ErrInvalidQuery, field)
+		return q
+	}
+
+	q.cond = field + operator + rendered
+	return q
+}
+
+// renderFilterValue renders a value in the filter syntax. Text and dates
+// are single quoted and dates are compared by their UTC day.
+func renderFilterValue(kind filterFieldKind, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "null", nil
+	case time.Time:
+		if kind != dateField && kind != customField {
+			return "", fmt.Errorf("a date cannot be compared with a %s", kindName(kind))
+		}
+		return "'" + v.UTC().Format("2006-01-02") + "'", nil
+	case *time.Time:
+		if v == nil {
+			return "null", nil
+		}
+		return renderFilterValue(kind, *v)
+	case string:
+		if kind == numberField {
+			return "", fmt.Errorf("text cannot be compared with a number")
+		}
+		if kind == dateField {
+			if _, err := time.Parse("2006-01-02", v); err != nil {
+				return "", fmt.Errorf("date %q is not in the YYYY-MM-DD format", v)
+			}
+		}
+		if strings.ContainsAny(v, `'"`) {
+			return "", fmt.Errorf("text %q cannot contain quotes", v)
+		}
+		return "'" + v + "'", nil
+	case bool:
+		if kind != customField {
+			return "", fmt.Errorf("a boolean cannot be compared with a %s", kindName(kind))
+		}
+		return strconv.FormatBool(v), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		if kind == textField || kind == dateField {
+			return "", fmt.Errorf("a number cannot be compared with a %s", kindName(kind))
+		}
+		return fmt.Sprint(v), nil
+	case float32, float64:
+		if kind != customField {
+			return "", fmt.Errorf("a decimal number cannot be compared with a %s", kindName(kind))
+		}
+		return fmt.Sprint(v), nil
+	}
+	return "", fmt.Errorf("unsupported value type %T", value)
+}
+
+func kindName(kind filterFieldKind) string {
+	switch kind {
+	case numberField:
+		return "number"
+	case textField:
+		return "text field"
+	case dateField:
+		return "date"
+	}
+	return "custom field"
+}
@@ -0,0 +1,110 @@
+package freshservice_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CoreyGriffin/go-freshservice/freshservice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryString(t *testing.T) {
+	q := freshservice.Q
+	since := time.Date(2020, 5, 1, 23, 30, 0, 0, time.FixedZone("PDT", -7*3600))
+
+	tests := []struct {
+		query *freshservice.Query
+		want  string
+	}{
+		{q.Eq("priority", 3), "priority:3"},
+		{q.Eq("agent_id", nil), "agent_id:null"},
+		{q.Eq("tag", "vip"), "tag:'vip'"},
+		{q.Gt("created_at", since), "created_at:>'2020-05-02'"},
+		{q.Lt("due_by", "2020-06-01"), "due_by:<'2020-06-01'"},
+		{q.Eq("hardware_broken", true), "hardware_broken:true"},
+		{q.Eq("priority", 3).And(q.Gt("created_at", since)), "priority:3 AND created_at:>'2020-05-02'"},
+		{q.Eq("priority", 3).And(q.Eq("status", 2)).Or(q.Eq("agent_id", nil)), "(priority:3 AND status:2) OR agent_id:null"},
+		{q.And(q.Eq("group_id", 1), q.Or(q.Eq("status", 2), q.Eq("status", 3))), "group_id:1 AND (status:2 OR status:3)"},
+		{q.Or(q.Eq("status", 2)).Or(q.Eq("status", 3), q.Eq("status", 4)), "status:2 OR status:3 OR status:4"},
+	}
+
+	for _, tt := range tests {
+		assert.Nil(t, tt.query.Validate(), tt.want)
+		assert.Equal(t, tt.want, tt.query.String())
+	}
+}
+
+func TestQueryValidate(t *testing.T) {
+	q := freshservice.Q
+
+	invalid := []*freshservice.Query{
+		q.Eq("Priority", 3),
+		q.Eq("priority; drop", 3),
+		q.Eq("priority", "high"),
+		q.Gt("tag", "vip"),
+		q.Gt("agent_id", nil),
+		q.Eq("created_at", "yesterday"),
+		q.Eq("created_at", 5),
+		q.Eq("tag", "it's"),
+		q.Eq("status", 2).And(q.Eq("status", []int{1})),
+		q.Eq("status", 2).Or(nil),
+		q.And(),
+		nil,
+	}
+	for _, query := range invalid {
+		err := query.Validate()
+		assert.True(t, errors.Is(err, freshservice.ErrInvalidQuery), fmt.Sprint(err))
+	}
+
+	long := q.Eq("tag", strings.Repeat("a", freshservice.MaxFilterQueryLength))
+	assert.True(t, errors.Is(long.Validate(), freshservice.ErrInvalidQuery))
+}
+
+func TestTicketFilter(t *testing.T) {
+	var queries []string
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/tickets/filter", r.URL.Path)
+		queries = append(queries, r.URL.Query().Get("query"))
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		assert.Equal(t, "2", r.URL.Query().Get("per_page"))
+		if page == 2 {
+			fmt.Fprint(w, `{"tickets":[{"id":3}],"total":3}`)
+			return
+		}
+		fmt.Fprint(w, `{"tickets":[{"id":1},{"id":2}],"total":3}`)
+	})
+	defer server.Close()
+
+	query := freshservice.Q.Eq("priority", 3).And(freshservice.Q.Eq("status", 2))
+
+	res, err := c.Tickets().Filter(context.Background(), query, &freshservice.FilterOptions{Page: &freshservice.PageCursor{PerPage: 2}})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, res.Total)
+	assert.Len(t, res.List, 2)
+	assert.Equal(t, `"priority:3 AND status:2"`, queries[0])
+
+	it := c.Tickets().FilterIter(context.Background(), query)
+	it.SetPerPage(2)
+
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []int{1, 2, 3}, ids)
+	assert.Len(t, queries, 3)
+
+	_, err = c.Tickets().Filter(context.Background(), freshservice.Q.Gt("tag", "x"), nil)
+	assert.True(t, errors.Is(err, freshservice.ErrInvalidQuery))
+	assert.Len(t, queries, 3)
+}
@@ -0,0 +1,122 @@
+package freshservice
+
+import "context"
+
+// MaxPerPage is the largest page size accepted by the Freshservice API
+const MaxPerPage = 100
+
+// fetchFunc requests a single page with the given filter, storing the
+// items in the typed iterator and returning the API response
+type fetchFunc func(context.Context, QueryFilter) (*Response, error)
+
+// pager holds the state shared by every list iterator. It follows the
+// Link header returned with each page until there are no more pages,
+// the item limit is reached, an error occurs or the context is done.
+type pager struct {
+	ctx     context.Context
+	filter  QueryFilter
+	fetch   fetchFunc
+	perPage int
+	limit   int
+	seen    int
+	next    QueryFilter
+	started bool
+	done    bool
+	err     error
+}
+
+// newPager returns a pager that will request the first page with filter
+func newPager(ctx context.Context, filter QueryFilter, fetch fetchFunc) pager {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return pager{ctx: ctx, filter: filter, fetch: fetch}
+}
+
+// SetPerPage sets the number of items requested per page, up to
+// MaxPerPage. It must be called before the first call to Next.
+func (p *pager) SetPerPage(n int) {
+	if n > MaxPerPage {
+		n = MaxPerPage
+	}
+	p.perPage = n
+}
+
+// SetLimit caps the total number of items returned by the iterator.
+// A limit of 0 returns every item. It must be called before the first call to Next.
+func (p *pager) SetLimit(n int) {
+	p.limit = n
+}
+
+// Err returns the first error encountered while iterating
+func (p *pager) Err() error {
+	return p.err
+}
+
+// fetchNext requests the next page, returning false once there are
+// no more pages, the item limit is reached or an error occurred
+func (p *pager) fetchNext() bool {
+	if p.done || p.err != nil {
+		return false
+	}
+
+	// no page is requested for items that take would not return
+	if p.limit > 0 && p.seen >= p.limit {
+		p.done = true
+		return false
+	}
+
+	if err := p.ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+
+	filter := p.next
+	if !p.started {
+		filter = p.firstPage()
+		p.started = true
+	}
+
+	resp, err := p.fetch(p.ctx, filter)
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	if resp.Next == nil {
+		p.done = true
+		return true
+	}
+
+	cursor := *resp.Next
+	if cursor.PerPage == 0 {
+		cursor.PerPage = p.perPage
+	}
+	p.next = withCursor(p.filter, &cursor)
+
+	return true
+}
+
+// firstPage returns the filter used for the first request
+func (p *pager) firstPage() QueryFilter {
+	if p.perPage <= 0 {
+		return p.filter
+	}
+	return withCursor(p.filter, &PageCursor{PerPage: p.perPage})
+}
+
+// take reports whether another item may be returned, counting it if so
+func (p *pager) take() bool {
+	if err := p.ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+
+	if p.limit > 0 && p.seen >= p.limit {
+		p.done = true
+		return false
+	}
+
+	p.seen++
+	return true
+}
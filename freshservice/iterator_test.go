@@ -0,0 +1,132 @@
+package freshservice_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/CoreyGriffin/go-freshservice/freshservice"
+	"github.com/stretchr/testify/assert"
+)
+
+// newPagedServer serves pages of tickets with two tickets per page,
+// linking to the next page until the last page is reached
+func newPagedServer(t *testing.T, pages int, queries *[]string) (*httptest.Server, *freshservice.Client) {
+	var server *httptest.Server
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r.URL.RawQuery)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+
+		if page < pages {
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=%d&per_page=2>; rel="next"`, server.URL, r.URL.Path, page+1))
+		}
+		fmt.Fprintf(w, `{"tickets":[{"id":%d},{"id":%d}]}`, page*2-1, page*2)
+	})
+
+	return server, c
+}
+
+func TestTicketIterator(t *testing.T) {
+	var queries []string
+	server, c := newPagedServer(t, 3, &queries)
+	defer server.Close()
+
+	it := c.Tickets().Iter(context.Background(), &freshservice.TicketListOptions{SortBy: &freshservice.SortOptions{Ascending: true}})
+	it.SetPerPage(2)
+
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, ids)
+	assert.Equal(t, []string{"order_type=asc&per_page=2", "order_type=asc&page=2&per_page=2", "order_type=asc&page=3&per_page=2"}, queries)
+}
+
+func TestTicketIteratorLimit(t *testing.T) {
+	var queries []string
+	server, c := newPagedServer(t, 3, &queries)
+	defer server.Close()
+
+	it := c.Tickets().Iter(context.Background(), nil)
+	it.SetLimit(3)
+	it.SetPerPage(500)
+
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []int{1, 2, 3}, ids)
+	assert.Equal(t, "per_page=100", queries[0])
+	assert.Len(t, queries, 2)
+}
+
+func TestTicketIteratorLimitEndOfPage(t *testing.T) {
+	for _, limit := range []int{2, 4} {
+		var queries []string
+		server, c := newPagedServer(t, 3, &queries)
+
+		it := c.Tickets().Iter(context.Background(), nil)
+		it.SetLimit(limit)
+
+		var ids []int
+		for it.Next() {
+			ids = append(ids, it.Value().ID)
+		}
+
+		// the page after the limit is never requested, even when
+		// Next is called again
+		assert.False(t, it.Next())
+		assert.Nil(t, it.Err())
+		assert.Len(t, ids, limit)
+		assert.Len(t, queries, limit/2, "limit %d", limit)
+		server.Close()
+	}
+}
+
+func TestListAll(t *testing.T) {
+	var queries []string
+	server, c := newPagedServer(t, 2, &queries)
+	defer server.Close()
+
+	tickets, err := c.Tickets().ListAll(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Len(t, tickets, 4)
+}
+
+func TestIteratorContextCancelled(t *testing.T) {
+	var queries []string
+	server, c := newPagedServer(t, 3, &queries)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := c.Tickets().Iter(ctx, nil)
+
+	assert.True(t, it.Next())
+	cancel()
+	assert.False(t, it.Next())
+	assert.True(t, errors.Is(it.Err(), context.Canceled))
+	assert.Len(t, queries, 1)
+}
+
+func TestIteratorError(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	defer server.Close()
+
+	tasks, err := c.Tasks().ListAll(context.Background(), 1)
+	assert.Nil(t, tasks)
+	assert.True(t, errors.Is(err, freshservice.ErrUnauthorized))
+}
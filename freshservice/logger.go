@@ -0,0 +1,310 @@
+package freshservice
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultMaxLogBodySize is the number of bytes of a body that are logged
+	defaultMaxLogBodySize = 4096
+	// redacted replaces sensitive values in log output
+	redacted = "[REDACTED]"
+)
+
+// defaultRedactFields are JSON fields and query parameters containing
+// personal data that are always redacted from logged traffic
+var defaultRedactFields = []string{
+	"email",
+	"emails",
+	"cc_emails",
+	"fwd_emails",
+	"reply_cc_emails",
+	"to_emails",
+	"additional_emails",
+	"secondary_emails",
+	"notify_emails",
+	"work_phone_number",
+	"mobile_phone_number",
+	"phone",
+	"address",
+}
+
+// Logger is the interface used by the client to emit log messages.
+// Arguments after the message are alternating keys and values, which
+// means a *slog.Logger from the standard library can be used directly.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// LogOptions controls what is recorded when API traffic is logged.
+// The API key is never logged and the values of known personal data
+// fields such as emails and phone numbers are always redacted.
+type LogOptions struct {
+	// LogHeaders includes request and response headers in the log
+	LogHeaders bool
+	// LogBodies includes request and response bodies in the log
+	LogBodies bool
+	// MaxBodySize is the number of bytes of each body that are read and
+	// logged, defaults to 4096. Larger bodies are not buffered in memory.
+	MaxBodySize int
+	// RedactFields are additional JSON fields and query parameters
+	// whose values are redacted
+	RedactFields []string
+}
+
+// trafficLogger is a Middleware that logs every request and response
+type trafficLogger struct {
+	logger Logger
+	opts   LogOptions
+	redact map[string]bool
+}
+
+// newTrafficLogger returns a trafficLogger with the redacted fields resolved
+func newTrafficLogger(l Logger, opts LogOptions) *trafficLogger {
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = defaultMaxLogBodySize
+	}
+
+	redact := map[string]bool{}
+	for _, f := range append(defaultRedactFields, opts.RedactFields...) {
+		redact[strings.ToLower(f)] = true
+	}
+
+	return &trafficLogger{logger: l, opts: opts, redact: redact}
+}
+
+// Wrap satisfies the Middleware interface
+func (tl *trafficLogger) Wrap(next RoundTripFunc) RoundTripFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		kv := []interface{}{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"query", tl.redactQuery(r.URL.Query()),
+		}
+
+		if tl.opts.LogHeaders {
+			kv = append(kv, "request_headers", redactHeaders(r.Header))
+		}
+
+		if tl.opts.LogBodies && r.GetBody != nil {
+			if body, err := r.GetBody(); err == nil {
+				b, _ := ioutil.ReadAll(io.LimitReader(body, int64(tl.opts.MaxBodySize)))
+				body.Close()
+				kv = append(kv, "request_body", tl.redactBody(b))
+			}
+		}
+
+		start := time.Now()
+		res, err := next(r)
+		kv = append(kv, "latency", time.Since(start))
+
+		if err != nil {
+			if res != nil {
+				res.Body.Close()
+			}
+			tl.logger.Error("Freshservice request failed", append(kv, "error", err)...)
+			return nil, err
+		}
+
+		kv = append(kv, "status", res.StatusCode)
+
+		if tl.opts.LogHeaders {
+			kv = append(kv, "response_headers", redactHeaders(res.Header))
+		}
+
+		if tl.opts.LogBodies {
+			// only the logged prefix is buffered, the rest of the body
+			// still streams from the original reader
+			prefix, err := ioutil.ReadAll(io.LimitReader(res.Body, int64(tl.opts.MaxBodySize)))
+			if err != nil {
+				res.Body.Close()
+				tl.logger.Error("Freshservice request failed", append(kv, "error", err)...)
+				return nil, err
+			}
+			res.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(prefix), res.Body), res.Body}
+			kv = append(kv, "response_body", tl.redactBody(prefix))
+		}
+
+		switch {
+		case res.StatusCode >= http.StatusInternalServerError:
+			tl.logger.Error("Freshservice request", kv...)
+		case res.StatusCode < http.StatusOK || res.StatusCode > 299:
+			tl.logger.Warn("Freshservice request", kv...)
+		default:
+			tl.logger.Info("Freshservice request", kv...)
+		}
+		return res, nil
+	}
+}
+
+// redactQuery returns the encoded query with sensitive parameters redacted
+func (tl *trafficLogger) redactQuery(q url.Values) string {
+	for k := range q {
+		if tl.redact[strings.ToLower(k)] {
+			q[k] = []string{redacted}
+		}
+	}
+	return q.Encode()
+}
+
+// redactBody returns a loggable copy of a JSON body with sensitive
+// fields redacted. Bodies that are not JSON are not logged since their
+// contents cannot be redacted. A body cut off at MaxBodySize is
+// redacted token by token up to where it was cut.
+func (tl *trafficLogger) redactBody(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		if len(b) < tl.opts.MaxBodySize {
+			return "[non-JSON body omitted]"
+		}
+		if partial := tl.redactPartial(b); partial != "" {
+			return partial + "...(truncated)"
+		}
+		return "[non-JSON body omitted]"
+	}
+
+	out, err := json.Marshal(tl.redactValue(v))
+	if err != nil {
+		return "[body omitted]"
+	}
+
+	if len(out) > tl.opts.MaxBodySize {
+		return string(out[:tl.opts.MaxBodySize]) + "...(truncated)"
+	}
+	return string(out)
+}
+
+// redactPartial re-encodes the complete tokens of a truncated JSON body,
+// replacing the values of sensitive fields, and stops at the first token
+// that is cut off
+func (tl *trafficLogger) redactPartial(b []byte) string {
+	type frame struct {
+		object bool
+		count  int // keys and values written to the container
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var out bytes.Buffer
+	var stack []*frame
+	redactNext := false
+
+	// write adds a key or value with the separator it needs
+	write := func(s string) {
+		if len(stack) > 0 {
+			f := stack[len(stack)-1]
+			switch {
+			case f.object && f.count%2 == 1:
+				out.WriteByte(':')
+			case f.count > 0:
+				out.WriteByte(',')
+			}
+			f.count++
+		}
+		out.WriteString(s)
+	}
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+
+		isKey := len(stack) > 0 && stack[len(stack)-1].object && stack[len(stack)-1].count%2 == 0
+		if delim, ok := tok.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			out.WriteString(delim.String())
+			continue
+		}
+
+		if redactNext {
+			redactNext = false
+			write(`"` + redacted + `"`)
+			if _, ok := tok.(json.Delim); ok {
+				// skip the tokens of a redacted object or array
+				for depth := 1; depth > 0; {
+					next, err := dec.Token()
+					if err != nil {
+						return out.String()
+					}
+					switch next {
+					case json.Delim('{'), json.Delim('['):
+						depth++
+					case json.Delim('}'), json.Delim(']'):
+						depth--
+					}
+				}
+			}
+			continue
+		}
+
+		switch val := tok.(type) {
+		case json.Delim:
+			write(val.String())
+			stack = append(stack, &frame{object: val == '{'})
+		case string:
+			if isKey && tl.redact[strings.ToLower(val)] {
+				redactNext = true
+			}
+			enc, _ := json.Marshal(val)
+			write(string(enc))
+		case json.Number:
+			write(val.String())
+		case bool:
+			enc, _ := json.Marshal(val)
+			write(string(enc))
+		case nil:
+			write("null")
+		}
+	}
+
+	return out.String()
+}
+
+// redactValue walks a decoded JSON value replacing sensitive fields
+func (tl *trafficLogger) redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if tl.redact[strings.ToLower(k)] {
+				val[k] = redacted
+				continue
+			}
+			val[k] = tl.redactValue(child)
+		}
+	case []interface{}:
+		for i, child := range val {
+			val[i] = tl.redactValue(child)
+		}
+	}
+	return v
+}
+
+// redactHeaders returns a copy of the headers with credentials removed
+func redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	for _, k := range []string{"Authorization", "Cookie", "Set-Cookie"} {
+		if _, ok := out[k]; ok {
+			out[k] = []string{redacted}
+		}
+	}
+	return out
+}
@@ -0,0 +1,182 @@
+package freshservice_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CoreyGriffin/go-freshservice/freshservice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientTrafficLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Contains(t, string(b), "agent@example.com")
+		fmt.Fprint(w, `{"agent":{"id":7,"email":"agent@example.com","mobile_phone_number":"555-0100","custom_fields":{"badge":"1234"}}}`)
+	}))
+	defer server.Close()
+
+	logger := &testLogger{}
+	c, err := freshservice.NewClient(domain, apiKey,
+		freshservice.WithBaseURL(server.URL),
+		freshservice.WithLogger(logger),
+		freshservice.WithLogOptions(freshservice.LogOptions{
+			LogHeaders:   true,
+			LogBodies:    true,
+			RedactFields: []string{"badge"},
+		}),
+	)
+	assert.Nil(t, err)
+
+	ad, err := c.Agents().Create(context.Background(), &freshservice.AgentDetails{FirstName: "Test", Email: "agent@example.com"})
+	assert.Nil(t, err)
+	assert.Equal(t, "agent@example.com", ad.Email)
+
+	assert.Len(t, logger.entries, 1)
+	entry := logger.entries[0]
+	assert.Equal(t, http.MethodPost, entry.Fields["method"])
+	assert.Equal(t, "/api/v2/agents", entry.Fields["path"])
+	assert.Equal(t, http.StatusOK, entry.Fields["status"])
+	assert.NotNil(t, entry.Fields["latency"])
+
+	reqHeaders := entry.Fields["request_headers"].(http.Header)
+	assert.Equal(t, "[REDACTED]", reqHeaders.Get("Authorization"))
+
+	reqBody := entry.Fields["request_body"].(string)
+	assert.Contains(t, reqBody, `"first_name":"Test"`)
+	assert.NotContains(t, reqBody, "agent@example.com")
+
+	resBody := entry.Fields["response_body"].(string)
+	assert.Contains(t, resBody, `"id":7`)
+	for _, secret := range []string{"agent@example.com", "555-0100", "1234"} {
+		assert.False(t, strings.Contains(resBody, secret), secret)
+	}
+}
+
+func TestClientTrafficLoggingQueryRedaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"agents":[]}`)
+	}))
+	defer server.Close()
+
+	logger := &testLogger{}
+	c, err := freshservice.NewClient(domain, apiKey, freshservice.WithBaseURL(server.URL), freshservice.WithLogger(logger))
+	assert.Nil(t, err)
+
+	_, _, err = c.Agents().List(context.Background(), &freshservice.AgentListFilter{Email: freshservice.String("agent@example.com")})
+	assert.Nil(t, err)
+
+	assert.Len(t, logger.entries, 1)
+	assert.Equal(t, "email=%5BREDACTED%5D", logger.entries[0].Fields["query"])
+	assert.Nil(t, logger.entries[0].Fields["response_body"])
+}
+
+func TestClientTrafficLoggingLargeBody(t *testing.T) {
+	var body strings.Builder
+	body.WriteString(`{"agents":[`)
+	for i := 1; i <= 200; i++ {
+		if i > 1 {
+			body.WriteString(",")
+		}
+		fmt.Fprintf(&body, `{"id":%d,"email":"agent%d@example.com","custom_fields":{"badge":"Paris"}}`, i, i)
+	}
+	body.WriteString(`]}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body.String())
+	}))
+	defer server.Close()
+
+	logger := &testLogger{}
+	c, err := freshservice.NewClient(domain, apiKey,
+		freshservice.WithBaseURL(server.URL),
+		freshservice.WithLogger(logger),
+		freshservice.WithLogOptions(freshservice.LogOptions{LogBodies: true, MaxBodySize: 100, RedactFields: []string{"custom_fields"}}),
+	)
+	assert.Nil(t, err)
+
+	// the whole body is still decoded after the logged prefix
+	agents, _, err := c.Agents().List(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Len(t, agents, 200)
+	assert.Equal(t, "agent200@example.com", agents[199].Email)
+
+	assert.Len(t, logger.entries, 1)
+	assert.Equal(t, "info", logger.entries[0].Level)
+	resBody := logger.entries[0].Fields["response_body"].(string)
+	assert.True(t, strings.HasPrefix(resBody, `{"agents":[{"id":1,"email":"[REDACTED]","custom_fields":"[REDACTED]"},{"id":2`), resBody)
+	assert.True(t, strings.HasSuffix(resBody, "...(truncated)"), resBody)
+	assert.NotContains(t, resBody, "example.com")
+	assert.NotContains(t, resBody, "Paris")
+}
+
+func TestClientTrafficLoggingLevels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/agents/1":
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/v2/agents/2":
+			w.WriteHeader(http.StatusBadGateway)
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	logger := &testLogger{}
+	c, err := freshservice.NewClient(domain, apiKey, freshservice.WithBaseURL(server.URL), freshservice.WithLogger(logger))
+	assert.Nil(t, err)
+
+	c.Agents().Get(context.Background(), 1)
+	c.Agents().Get(context.Background(), 2)
+	c.Agents().Get(context.Background(), 3)
+
+	var levels []string
+	for _, e := range logger.entries {
+		levels = append(levels, e.Level)
+	}
+	assert.Equal(t, []string{"warn", "error", "info"}, levels)
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("connection reset") }
+
+func TestClientTrafficLoggingBodyReadError(t *testing.T) {
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(failingReader{}),
+			Request:    r,
+		}, nil
+	})
+
+	logger := &testLogger{}
+	c, err := freshservice.NewClient(domain, apiKey,
+		freshservice.WithHTTPClient(&http.Client{Transport: transport}),
+		freshservice.WithLogger(logger),
+		freshservice.WithLogOptions(freshservice.LogOptions{LogBodies: true}),
+	)
+	assert.Nil(t, err)
+
+	// middleware around the logger sees either a response or an error
+	var res *http.Response
+	c.Use(freshservice.MiddlewareFunc(func(next freshservice.RoundTripFunc) freshservice.RoundTripFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			res, err = next(r)
+			return res, err
+		}
+	}))
+
+	_, getErr := c.Agents().Get(context.Background(), 1)
+	assert.NotNil(t, getErr)
+	assert.Nil(t, res)
+	assert.Contains(t, err.Error(), "connection reset")
+	assert.Equal(t, "error", logger.entries[0].Level)
+}
@@ -0,0 +1,260 @@
+package freshservice
+
+import (
+	"bufio"
+	"expvar"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the
+// request latency histogram used when none are provided
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Metrics collects request counts, errors and latency for every
+// Freshservice endpoint called by a client. Endpoints are keyed by HTTP
+// method and a route template where IDs are replaced with {id}, for
+// example GET /api/v2/tickets/{id}/tasks. Metrics satisfies the Middleware
+// interface and can be shared between clients.
+type Metrics struct {
+	mu      sync.Mutex
+	buckets []float64
+	routes  map[routeKey]*routeMetrics
+}
+
+type routeKey struct {
+	method string
+	route  string
+}
+
+type routeMetrics struct {
+	codes        map[int]uint64
+	errors       uint64
+	bucketCounts []uint64
+	latencySum   float64
+	latencyCount uint64
+}
+
+// RouteStats is a snapshot of the metrics collected for a single endpoint
+type RouteStats struct {
+	Method         string            `json:"method"`
+	Route          string            `json:"route"`
+	Requests       map[string]uint64 `json:"requests"` // keyed by HTTP status code
+	Errors         uint64            `json:"errors"`   // requests that failed without a response
+	LatencySeconds LatencyStats      `json:"latency_seconds"`
+}
+
+// LatencyStats is a snapshot of a latency histogram
+type LatencyStats struct {
+	Buckets map[string]uint64 `json:"buckets"` // cumulative counts keyed by upper bound
+	Sum     float64           `json:"sum"`
+	Count   uint64            `json:"count"`
+}
+
+// NewMetrics returns a metrics collector using the given latency histogram
+// bucket upper bounds in seconds, or DefaultLatencyBuckets if none are given
+func NewMetrics(buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+
+	b := append([]float64(nil), buckets...)
+	sort.Float64s(b)
+
+	return &Metrics{
+		buckets: b,
+		routes:  map[routeKey]*routeMetrics{},
+	}
+}
+
+// Wrap satisfies the Middleware interface, recording every request
+func (m *Metrics) Wrap(next RoundTripFunc) RoundTripFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		start := time.Now()
+		res, err := next(r)
+
+		code := 0
+		if err == nil {
+			code = res.StatusCode
+		}
+		m.observe(r.Method, routeTemplate(r.URL.Path), code, time.Since(start))
+
+		return res, err
+	}
+}
+
+// observe records a single request, a code of 0 marks a failed request
+func (m *Metrics) observe(method string, route string, code int, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := routeKey{method: method, route: route}
+	rm, ok := m.routes[key]
+	if !ok {
+		rm = &routeMetrics{
+			codes:        map[int]uint64{},
+			bucketCounts: make([]uint64, len(m.buckets)),
+		}
+		m.routes[key] = rm
+	}
+
+	if code == 0 {
+		rm.errors++
+	} else {
+		rm.codes[code]++
+	}
+
+	secs := latency.Seconds()
+	for i, upper := range m.buckets {
+		if secs <= upper {
+			rm.bucketCounts[i]++
+		}
+	}
+	rm.latencySum += secs
+	rm.latencyCount++
+}
+
+// Snapshot returns the metrics collected so far ordered by route and method
+func (m *Metrics) Snapshot() []RouteStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := make([]RouteStats, 0, len(m.routes))
+	for _, key := range m.sortedKeys() {
+		rm := m.routes[key]
+
+		requests := map[string]uint64{}
+		for code, n := range rm.codes {
+			requests[strconv.Itoa(code)] = n
+		}
+
+		buckets := map[string]uint64{}
+		for i, upper := range m.buckets {
+			buckets[formatFloat(upper)] = rm.bucketCounts[i]
+		}
+		buckets["+Inf"] = rm.latencyCount
+
+		stats = append(stats, RouteStats{
+			Method:   key.method,
+			Route:    key.route,
+			Requests: requests,
+			Errors:   rm.errors,
+			LatencySeconds: LatencyStats{
+				Buckets: buckets,
+				Sum:     rm.latencySum,
+				Count:   rm.latencyCount,
+			},
+		})
+	}
+
+	return stats
+}
+
+// Publish exposes the metrics through expvar under the given name.
+// Like expvar.Publish it panics if the name is already registered.
+func (m *Metrics) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return m.Snapshot()
+	}))
+}
+
+// Handler returns a http.Handler that renders the metrics
+// in the Prometheus text exposition format
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		bw := bufio.NewWriter(w)
+		m.writePrometheus(bw)
+		bw.Flush()
+	})
+}
+
+// writePrometheus renders the metrics in the Prometheus text exposition format
+func (m *Metrics) writePrometheus(w *bufio.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := m.sortedKeys()
+
+	fmt.Fprintln(w, "# HELP freshservice_requests_total Freshservice API requests that received a response, by status code.")
+	fmt.Fprintln(w, "# TYPE freshservice_requests_total counter")
+	for _, key := range keys {
+		rm := m.routes[key]
+		codes := make([]int, 0, len(rm.codes))
+		for code := range rm.codes {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+		for _, code := range codes {
+			fmt.Fprintf(w, "freshservice_requests_total{%s,code=\"%d\"} %d\n", key.labels(), code, rm.codes[code])
+		}
+	}
+
+	fmt.Fprintln(w, "# HELP freshservice_request_errors_total Freshservice API requests that failed without a response.")
+	fmt.Fprintln(w, "# TYPE freshservice_request_errors_total counter")
+	for _, key := range keys {
+		fmt.Fprintf(w, "freshservice_request_errors_total{%s} %d\n", key.labels(), m.routes[key].errors)
+	}
+
+	fmt.Fprintln(w, "# HELP freshservice_request_duration_seconds Freshservice API request latency.")
+	fmt.Fprintln(w, "# TYPE freshservice_request_duration_seconds histogram")
+	for _, key := range keys {
+		rm := m.routes[key]
+		for i, upper := range m.buckets {
+			fmt.Fprintf(w, "freshservice_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n", key.labels(), formatFloat(upper), rm.bucketCounts[i])
+		}
+		fmt.Fprintf(w, "freshservice_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", key.labels(), rm.latencyCount)
+		fmt.Fprintf(w, "freshservice_request_duration_seconds_sum{%s} %s\n", key.labels(), formatFloat(rm.latencySum))
+		fmt.Fprintf(w, "freshservice_request_duration_seconds_count{%s} %d\n", key.labels(), rm.latencyCount)
+	}
+}
+
+// sortedKeys returns the route keys in a stable order, it must
+// be called with the lock held
+func (m *Metrics) sortedKeys() []routeKey {
+	keys := make([]routeKey, 0, len(m.routes))
+	for key := range m.routes {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].route != keys[j].route {
+			return keys[i].route < keys[j].route
+		}
+		return keys[i].method < keys[j].method
+	})
+	return keys
+}
+
+// labels renders the Prometheus labels identifying a route
+func (k routeKey) labels() string {
+	return fmt.Sprintf("method=\"%s\",route=\"%s\"", escapeLabel(k.method), escapeLabel(k.route))
+}
+
+// routeTemplate normalises a request path by replacing numeric
+// IDs with {id} so that metrics are not keyed per resource
+func routeTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if s == "" {
+			continue
+		}
+		if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// escapeLabel escapes a Prometheus label value
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,95 @@
+package freshservice
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteTemplate(t *testing.T) {
+	cases := map[string]string{
+		"/api/v2/tickets":                "/api/v2/tickets",
+		"/api/v2/tickets/123":            "/api/v2/tickets/{id}",
+		"/api/v2/tickets/123/tasks/4":    "/api/v2/tickets/{id}/tasks/{id}",
+		"/api/v2/agents/9/forget":        "/api/v2/agents/{id}/forget",
+		"/api/v2/service_catalog/items/": "/api/v2/service_catalog/items/",
+	}
+
+	for path, expected := range cases {
+		assert.Equal(t, expected, routeTemplate(path))
+	}
+}
+
+func TestMetricsPrometheusHandler(t *testing.T) {
+	m := NewMetrics(0.1, 1)
+	m.observe(http.MethodGet, "/api/v2/tickets/{id}", http.StatusOK, 50*time.Millisecond)
+	m.observe(http.MethodGet, "/api/v2/tickets/{id}", http.StatusNotFound, 500*time.Millisecond)
+	m.observe(http.MethodPost, "/api/v2/tickets/{id}/tasks", 0, 2*time.Second)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	expected := `# HELP freshservice_requests_total Freshservice API requests that received a response, by status code.
+# TYPE freshservice_requests_total counter
+freshservice_requests_total{method="GET",route="/api/v2/tickets/{id}",code="200"} 1
+freshservice_requests_total{method="GET",route="/api/v2/tickets/{id}",code="404"} 1
+# HELP freshservice_request_errors_total Freshservice API requests that failed without a response.
+# TYPE freshservice_request_errors_total counter
+freshservice_request_errors_total{method="GET",route="/api/v2/tickets/{id}"} 0
+freshservice_request_errors_total{method="POST",route="/api/v2/tickets/{id}/tasks"} 1
+# HELP freshservice_request_duration_seconds Freshservice API request latency.
+# TYPE freshservice_request_duration_seconds histogram
+freshservice_request_duration_seconds_bucket{method="GET",route="/api/v2/tickets/{id}",le="0.1"} 1
+freshservice_request_duration_seconds_bucket{method="GET",route="/api/v2/tickets/{id}",le="1"} 2
+freshservice_request_duration_seconds_bucket{method="GET",route="/api/v2/tickets/{id}",le="+Inf"} 2
+freshservice_request_duration_seconds_sum{method="GET",route="/api/v2/tickets/{id}"} 0.55
+freshservice_request_duration_seconds_count{method="GET",route="/api/v2/tickets/{id}"} 2
+freshservice_request_duration_seconds_bucket{method="POST",route="/api/v2/tickets/{id}/tasks",le="0.1"} 0
+freshservice_request_duration_seconds_bucket{method="POST",route="/api/v2/tickets/{id}/tasks",le="1"} 0
+freshservice_request_duration_seconds_bucket{method="POST",route="/api/v2/tickets/{id}/tasks",le="+Inf"} 1
+freshservice_request_duration_seconds_sum{method="POST",route="/api/v2/tickets/{id}/tasks"} 2
+freshservice_request_duration_seconds_count{method="POST",route="/api/v2/tickets/{id}/tasks"} 1
+`
+	assert.Equal(t, expected, rec.Body.String())
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+}
+
+func TestMetricsClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"tasks":[]}`)
+	}))
+	defer server.Close()
+
+	m := NewMetrics()
+	c, err := NewClient("domain.freshservice.com", "key", WithBaseURL(server.URL), WithMetrics(m))
+	assert.Nil(t, err)
+
+	_, err = c.Tasks().List(context.Background(), 12)
+	assert.Nil(t, err)
+	_, err = c.Tasks().List(context.Background(), 34)
+	assert.Nil(t, err)
+
+	stats := m.Snapshot()
+	assert.Len(t, stats, 1)
+	assert.Equal(t, http.MethodGet, stats[0].Method)
+	assert.Equal(t, "/api/v2/tickets/{id}/tasks", stats[0].Route)
+	assert.Equal(t, uint64(2), stats[0].Requests["200"])
+	assert.Equal(t, uint64(2), stats[0].LatencySeconds.Count)
+
+	m.Publish("freshservice_test")
+	v := expvar.Get("freshservice_test")
+	assert.NotNil(t, v)
+	assert.Contains(t, v.String(), `"route":"/api/v2/tickets/{id}/tasks"`)
+
+	res := httptest.NewRecorder()
+	m.Handler().ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := ioutil.ReadAll(res.Body)
+	assert.Contains(t, string(body), `freshservice_requests_total{method="GET",route="/api/v2/tickets/{id}/tasks",code="200"} 2`)
+}
@@ -0,0 +1,71 @@
+package freshservice
+
+import (
+	"net/http"
+)
+
+// RoundTripFunc sends a single HTTP request and returns its response
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// Middleware wraps the step that sends every request made by the
+// client, allowing requests and responses to be inspected or modified.
+// Middleware runs for each attempt of a request, including retries.
+type Middleware interface {
+	Wrap(next RoundTripFunc) RoundTripFunc
+}
+
+// MiddlewareFunc allows an ordinary function to be used as a Middleware
+type MiddlewareFunc func(next RoundTripFunc) RoundTripFunc
+
+// Wrap calls f(next)
+func (f MiddlewareFunc) Wrap(next RoundTripFunc) RoundTripFunc {
+	return f(next)
+}
+
+// HeaderMiddleware sets the given headers on every request, replacing
+// any existing values for the same keys
+func HeaderMiddleware(h http.Header) Middleware {
+	return MiddlewareFunc(func(next RoundTripFunc) RoundTripFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			r = r.Clone(r.Context())
+			for k, v := range h {
+				r.Header[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+			}
+			return next(r)
+		}
+	})
+}
+
+// LoggingMiddleware logs the method, path, query, status and latency of
+// every request. Personal data such as emails and phone numbers is redacted.
+func LoggingMiddleware(l Logger) Middleware {
+	return newTrafficLogger(l, LogOptions{})
+}
+
+// LoggingMiddlewareWithOptions logs every request, optionally including
+// headers and bodies, as configured by the given options
+func LoggingMiddlewareWithOptions(l Logger, opts LogOptions) Middleware {
+	return newTrafficLogger(l, opts)
+}
+
+// Use appends middleware to the chain run for every request. The first
+// middleware added is the outermost and sees the request first.
+func (fs *Client) Use(m ...Middleware) {
+	fs.middleware = append(fs.middleware, m...)
+}
+
+// roundTrip sends the request with the HTTP client c through the
+// client's middleware chain
+func (fs *Client) roundTrip(c *http.Client, r *http.Request) (*http.Response, error) {
+	next := RoundTripFunc(c.Do)
+
+	// traffic is logged innermost so it reflects what is sent on the wire
+	if fs.trafficLog != nil {
+		next = fs.trafficLog.Wrap(next)
+	}
+
+	for i := len(fs.middleware) - 1; i >= 0; i-- {
+		next = fs.middleware[i].Wrap(next)
+	}
+	return next(r)
+}
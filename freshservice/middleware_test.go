@@ -0,0 +1,97 @@
+package freshservice_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/CoreyGriffin/go-freshservice/freshservice"
+	"github.com/stretchr/testify/assert"
+)
+
+type logEntry struct {
+	Level  string
+	Msg    string
+	Fields map[string]interface{}
+}
+
+// testLogger records log entries so tests can assert on them
+type testLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *testLogger) log(level, msg string, kv ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fields := map[string]interface{}{}
+	for i := 0; i+1 < len(kv); i += 2 {
+		fields[fmt.Sprint(kv[i])] = kv[i+1]
+	}
+	l.entries = append(l.entries, logEntry{Level: level, Msg: msg, Fields: fields})
+}
+
+func (l *testLogger) Debug(msg string, kv ...interface{}) { l.log("debug", msg, kv...) }
+func (l *testLogger) Info(msg string, kv ...interface{})  { l.log("info", msg, kv...) }
+func (l *testLogger) Warn(msg string, kv ...interface{})  { l.log("warn", msg, kv...) }
+func (l *testLogger) Error(msg string, kv ...interface{}) { l.log("error", msg, kv...) }
+
+func TestMiddlewareOrder(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"agent":{"id":1}}`)
+	})
+	defer server.Close()
+
+	var calls []string
+	trace := func(name string) freshservice.Middleware {
+		return freshservice.MiddlewareFunc(func(next freshservice.RoundTripFunc) freshservice.RoundTripFunc {
+			return func(r *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" before")
+				res, err := next(r)
+				calls = append(calls, name+" after")
+				return res, err
+			}
+		})
+	}
+
+	c.Use(trace("outer"), trace("inner"))
+
+	_, err := c.Agents().Get(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"outer before", "inner before", "inner after", "outer after"}, calls)
+}
+
+func TestHeaderMiddleware(t *testing.T) {
+	var got string
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("X-Request-Source")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer server.Close()
+
+	c.Use(freshservice.HeaderMiddleware(http.Header{"x-request-source": []string{"nightly-sync"}}))
+
+	assert.Nil(t, c.Tickets().Delete(context.Background(), 1))
+	assert.Equal(t, "nightly-sync", got)
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer server.Close()
+
+	logger := &testLogger{}
+	c.Use(freshservice.LoggingMiddleware(logger))
+
+	_, err := c.Agents().Get(context.Background(), 42)
+	assert.NotNil(t, err)
+	assert.Len(t, logger.entries, 1)
+	assert.Equal(t, "warn", logger.entries[0].Level)
+	assert.Equal(t, http.MethodGet, logger.entries[0].Fields["method"])
+	assert.Equal(t, "/api/v2/agents/42", logger.entries[0].Fields["path"])
+	assert.Equal(t, http.StatusNotFound, logger.entries[0].Fields["status"])
+}
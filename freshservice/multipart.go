@@ -0,0 +1,280 @@
+package freshservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MaxAttachmentSize is the total size limit, in bytes, of the
+// attachments uploaded with a single Freshservice request
+const MaxAttachmentSize = 40 << 20
+
+// AttachmentFile is a file uploaded as an attachment. The content is
+// streamed from Reader so whole files are never held in memory.
+type AttachmentFile struct {
+	Name        string
+	ContentType string
+	Reader      io.Reader
+	// Size is optional. When the size of every file is known the size
+	// limit is checked before anything is sent and progress callbacks
+	// receive the total number of bytes to upload.
+	Size int64
+}
+
+// ProgressFunc is called as attachment content is uploaded with the
+// number of bytes sent so far and the total, or -1 if it is not known
+type ProgressFunc func(sent, total int64)
+
+// UploadOptions controls how attachments are uploaded
+type UploadOptions struct {
+	// MaxSize overrides the total size limit of the attachments,
+	// defaulting to MaxAttachmentSize
+	MaxSize int64
+	// Progress is called after each chunk of attachment content is
+	// sent. It is called from the goroutine writing the request body.
+	Progress ProgressFunc
+}
+
+// readOnlyFormFields are returned by Freshservice but are rejected
+// when sent with a multipart create or update
+var readOnlyFormFields = map[string]bool{
+	"id":               true,
+	"attachments":      true,
+	"created_at":       true,
+	"updated_at":       true,
+	"deleted":          true,
+	"description_text": true,
+	"fr_escalated":     true,
+	"is_escalated":     true,
+}
+
+// newMultipartRequest builds a request with a multipart/form-data body
+// holding the fields of v followed by the files, as attachments[]. The
+// body is written by a goroutine through a pipe as it is sent.
+func (fs *Client) newMultipartRequest(ctx context.Context, method string, path string, v interface{}, files []AttachmentFile, opts *UploadOptions) (*http.Request, error) {
+	fields, err := formFields(v)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding %s %s request body: %w", method, path, err)
+	}
+	return fs.newMultipartFormRequest(ctx, method, path, fields, files, opts)
+}
+
+// newMultipartFormRequest is the same as newMultipartRequest for fields
+// that have already been encoded
+func (fs *Client) newMultipartFormRequest(ctx context.Context, method string, path string, fields []formField, files []AttachmentFile, opts *UploadOptions) (*http.Request, error) {
+	var o UploadOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.MaxSize <= 0 {
+		o.MaxSize = MaxAttachmentSize
+	}
+
+	// fail before sending anything when the size of every file is known
+	total := int64(0)
+	for _, f := range files {
+		if f.Size <= 0 {
+			total = -1
+			break
+		}
+		total += f.Size
+	}
+	if total > o.MaxSize {
+		return nil, fmt.Errorf("error uploading %d bytes to %s %s: %w", total, method, path, ErrAttachmentTooLarge)
+	}
+
+	req, err := fs.newRequest(ctx, method, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	req.Body = &multipartBody{pr: pr, write: func() {
+		pw.CloseWithError(writeMultipart(mw, fields, files, &uploadCounter{max: o.MaxSize, total: total, progress: o.Progress}))
+	}}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	return req, nil
+}
+
+// multipartBody streams a multipart form through a pipe. The form is only
+// written once the body is first read, so a request that is never sent
+// does not leave a writer blocked on the pipe or hold the attachment readers.
+type multipartBody struct {
+	once  sync.Once
+	pr    *io.PipeReader
+	write func()
+}
+
+// Read starts writing the form on the first call
+func (b *multipartBody) Read(p []byte) (int, error) {
+	b.once.Do(func() { go b.write() })
+	return b.pr.Read(p)
+}
+
+// Close stops the writer, or prevents it from starting when the body was never read
+func (b *multipartBody) Close() error {
+	b.once.Do(func() {})
+	return b.pr.Close()
+}
+
+// writeMultipart writes the form fields and files, returning the
+// first error so it is passed on to the reading end of the pipe
+func writeMultipart(mw *multipart.Writer, fields []formField, files []AttachmentFile, counter *uploadCounter) error {
+	for _, f := range fields {
+		if err := mw.WriteField(f.name, f.value); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range files {
+		contentType := f.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="attachments[]"; filename="%s"`, escapeQuotes(f.Name)))
+		h.Set("Content-Type", contentType)
+
+		part, err := mw.CreatePart(h)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(part, &countingReader{r: f.Reader, counter: counter}); err != nil {
+			return fmt.Errorf("error uploading attachment %q: %w", f.Name, err)
+		}
+	}
+
+	return mw.Close()
+}
+
+// uploadCounter tracks the attachment bytes sent, enforcing the size
+// limit and reporting progress
+type uploadCounter struct {
+	sent     int64
+	max      int64
+	total    int64
+	progress ProgressFunc
+}
+
+// add records n more bytes sent
+func (c *uploadCounter) add(n int) error {
+	c.sent += int64(n)
+	if c.sent > c.max {
+		return ErrAttachmentTooLarge
+	}
+	if c.progress != nil && n > 0 {
+		c.progress(c.sent, c.total)
+	}
+	return nil
+}
+
+type countingReader struct {
+	r       io.Reader
+	counter *uploadCounter
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	if cerr := cr.counter.add(n); cerr != nil {
+		return n, cerr
+	}
+	return n, err
+}
+
+type formField struct {
+	name  string
+	value string
+}
+
+// formFields flattens the JSON encoding of v into form fields the way
+// Freshservice expects them, arrays as name[] and objects such as custom
+// fields as name[key]. Read-only fields and zero values are left out.
+func formFields(v interface{}) ([]formField, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var obj map[string]interface{}
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+
+	var fields []formField
+	for _, k := range sortedKeys(obj) {
+		if readOnlyFormFields[k] {
+			continue
+		}
+		fields = appendFormField(fields, k, obj[k], true)
+	}
+	return fields, nil
+}
+
+// appendFormField appends the fields encoding a single JSON value. Zero
+// values are only omitted for top level fields, a custom field set to
+// false or 0 is sent.
+func appendFormField(fields []formField, name string, v interface{}, omitZero bool) []formField {
+	switch val := v.(type) {
+	case nil:
+		return fields
+	case map[string]interface{}:
+		for _, k := range sortedKeys(val) {
+			fields = appendFormField(fields, name+"["+k+"]", val[k], false)
+		}
+		return fields
+	case []interface{}:
+		for _, item := range val {
+			fields = appendFormField(fields, name+"[]", item, false)
+		}
+		return fields
+	case string:
+		if omitZero && (val == "" || val == zeroTime) {
+			return fields
+		}
+		return append(fields, formField{name, val})
+	case json.Number:
+		if omitZero && val == "0" {
+			return fields
+		}
+		return append(fields, formField{name, val.String()})
+	case bool:
+		if omitZero && !val {
+			return fields
+		}
+		return append(fields, formField{name, fmt.Sprint(val)})
+	}
+	return append(fields, formField{name, fmt.Sprint(v)})
+}
+
+// zeroTime is the JSON encoding of an unset time.Time
+const zeroTime = "0001-01-01T00:00:00Z"
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func escapeQuotes(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
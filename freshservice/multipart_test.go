@@ -0,0 +1,121 @@
+package freshservice_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CoreyGriffin/go-freshservice/freshservice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateWithAttachment(t *testing.T) {
+	content := strings.Repeat("log line\n", 10000)
+
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v2/tickets", r.URL.Path)
+		assert.True(t, strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data; boundary="))
+
+		assert.Nil(t, r.ParseMultipartForm(1<<20))
+		assert.Equal(t, []string{"a@example.com", "b@example.com"}, r.MultipartForm.Value["cc_emails[]"])
+		assert.Equal(t, []string{"Printer on fire"}, r.MultipartForm.Value["subject"])
+		assert.Equal(t, []string{"2"}, r.MultipartForm.Value["status"])
+		assert.Equal(t, []string{"false"}, r.MultipartForm.Value["custom_fields[hardware]"])
+		assert.Equal(t, []string{"3"}, r.MultipartForm.Value["custom_fields[floor]"])
+		assert.NotContains(t, r.MultipartForm.Value, "id")
+		assert.NotContains(t, r.MultipartForm.Value, "due_by")
+		assert.NotContains(t, r.MultipartForm.Value, "spam")
+		assert.NotContains(t, r.MultipartForm.Value, "fr_escalated")
+
+		files := r.MultipartForm.File["attachments[]"]
+		assert.Len(t, files, 2)
+		assert.Equal(t, "printer.log", files[0].Filename)
+		assert.Equal(t, "text/plain", files[0].Header.Get("Content-Type"))
+		assert.Equal(t, "application/octet-stream", files[1].Header.Get("Content-Type"))
+
+		f, _ := files[0].Open()
+		b, _ := ioutil.ReadAll(f)
+		assert.Equal(t, content, string(b))
+
+		fmt.Fprint(w, `{"ticket":{"id":1,"attachments":[{"name":"printer.log"},{"name":"photo.jpg"}]}}`)
+	})
+	defer server.Close()
+
+	var sent, total int64
+	td := &freshservice.TicketDetails{
+		Subject:      "Printer on fire",
+		Status:       freshservice.TicketOpen,
+		CcEmails:     []string{"a@example.com", "b@example.com"},
+		CustomFields: freshservice.CustomFields{"hardware": false, "floor": 3},
+	}
+	files := []freshservice.AttachmentFile{
+		{Name: "printer.log", ContentType: "text/plain", Reader: strings.NewReader(content), Size: int64(len(content))},
+		{Name: "photo.jpg", Reader: bytes.NewReader([]byte{0xff, 0xd8}), Size: 2},
+	}
+	opts := &freshservice.UploadOptions{Progress: func(s, t int64) { sent, total = s, t }}
+
+	ticket, err := c.Tickets().CreateWithAttachment(context.Background(), td, files, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, ticket.ID)
+	assert.Len(t, ticket.Attachments, 2)
+	assert.Equal(t, int64(len(content)+2), sent)
+	assert.Equal(t, int64(len(content)+2), total)
+}
+
+func TestUpdateWithAttachmentSizeLimit(t *testing.T) {
+	var requests int
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		ioutil.ReadAll(r.Body)
+		fmt.Fprint(w, `{"ticket":{"id":1}}`)
+	})
+	defer server.Close()
+
+	opts := &freshservice.UploadOptions{MaxSize: 10}
+
+	// rejected up front when the size is known
+	files := []freshservice.AttachmentFile{{Name: "big.bin", Reader: strings.NewReader(strings.Repeat("x", 11)), Size: 11}}
+	_, err := c.Tickets().UpdateWithAttachment(context.Background(), 1, &freshservice.TicketDetails{}, files, opts)
+	assert.True(t, errors.Is(err, freshservice.ErrAttachmentTooLarge))
+	assert.Equal(t, 0, requests)
+
+	// aborted while streaming otherwise
+	files = []freshservice.AttachmentFile{{Name: "big.bin", Reader: strings.NewReader(strings.Repeat("x", 11))}}
+	_, err = c.Tickets().UpdateWithAttachment(context.Background(), 1, &freshservice.TicketDetails{}, files, opts)
+	assert.True(t, errors.Is(err, freshservice.ErrAttachmentTooLarge))
+}
+
+func TestUpdateWithAttachmentNotSent(t *testing.T) {
+	c, err := freshservice.NewClient(domain, apiKey)
+	assert.Nil(t, err)
+
+	// a middleware that fails without sending the request never reads the body
+	failed := errors.New("offline")
+	c.Use(freshservice.MiddlewareFunc(func(next freshservice.RoundTripFunc) freshservice.RoundTripFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			return nil, failed
+		}
+	}))
+
+	before := runtime.NumGoroutine()
+	for i := 0; i < 10; i++ {
+		files := []freshservice.AttachmentFile{{Name: "log.txt", Reader: strings.NewReader("boot ok")}}
+		_, err = c.Tickets().UpdateWithAttachment(context.Background(), 1, &freshservice.TicketDetails{Subject: "Crash"}, files, nil)
+		assert.True(t, errors.Is(err, failed))
+	}
+
+	// no multipart writer is left blocked on the unread body
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.True(t, runtime.NumGoroutine() <= before, "%d goroutines, %d before", runtime.NumGoroutine(), before)
+}
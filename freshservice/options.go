@@ -0,0 +1,111 @@
+package freshservice
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option configures a Client created with NewClient
+type Option func(*Client) error
+
+// WithHTTPClient sets the HTTP client used to make API requests
+func WithHTTPClient(client *http.Client) Option {
+	return func(fs *Client) error {
+		if client == nil {
+			return errors.New("freshservice: HTTP client must not be nil")
+		}
+		fs.client = client
+		return nil
+	}
+}
+
+// WithBaseURL sends requests to the given scheme and host, plus an
+// optional path prefix, instead of https://[domain]. This is useful
+// for proxies and for testing against a local server.
+func WithBaseURL(rawURL string) Option {
+	return func(fs *Client) error {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return fmt.Errorf("freshservice: invalid base URL: %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("freshservice: base URL %q must include a scheme and host", rawURL)
+		}
+		u.RawQuery = ""
+		u.Fragment = ""
+		fs.baseURL = u
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(ua string) Option {
+	return func(fs *Client) error {
+		fs.userAgent = ua
+		return nil
+	}
+}
+
+// WithRetryPolicy enables retries of rate limited and failed requests
+func WithRetryPolicy(p *RetryPolicy) Option {
+	return func(fs *Client) error {
+		fs.RetryPolicy = p
+		return nil
+	}
+}
+
+// WithCreditLimiter budgets the API credits spent by the client
+func WithCreditLimiter(l *CreditLimiter) Option {
+	return func(fs *Client) error {
+		fs.CreditLimiter = l
+		return nil
+	}
+}
+
+// WithLogger enables logging of API traffic and retries to the given
+// logger. Logging is disabled by default.
+func WithLogger(l Logger) Option {
+	return func(fs *Client) error {
+		fs.logger = l
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of the HTTP client. A HTTP client passed
+// with WithHTTPClient is copied rather than modified.
+func WithTimeout(d time.Duration) Option {
+	return func(fs *Client) error {
+		if d <= 0 {
+			return errors.New("freshservice: timeout must be greater than zero")
+		}
+		fs.timeout = d
+		return nil
+	}
+}
+
+// WithMiddleware adds middleware to the chain run for every request
+func WithMiddleware(m ...Middleware) Option {
+	return func(fs *Client) error {
+		fs.Use(m...)
+		return nil
+	}
+}
+
+// WithLogOptions controls what is recorded when traffic is logged
+func WithLogOptions(opts LogOptions) Option {
+	return func(fs *Client) error {
+		fs.logOptions = opts
+		return nil
+	}
+}
+
+// WithMetrics records request counts, errors and latency per endpoint
+func WithMetrics(m *Metrics) Option {
+	return func(fs *Client) error {
+		fs.Use(m)
+		return nil
+	}
+}
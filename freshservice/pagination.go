@@ -0,0 +1,260 @@
+package freshservice
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PageCursor identifies a page of a list request. It only carries the
+// page number and page size so it can be combined with any list options
+// or filter without duplicating or replacing their query parameters.
+type PageCursor struct {
+	Page    int
+	PerPage int
+}
+
+// QueryString allows a PageCursor to be used as a QueryFilter on its own
+func (pc *PageCursor) QueryString() string {
+	return pc.values().Encode()
+}
+
+// values returns the page parameters set on the cursor
+func (pc *PageCursor) values() url.Values {
+	v := url.Values{}
+	if pc == nil {
+		return v
+	}
+	if pc.Page > 0 {
+		v.Set("page", strconv.Itoa(pc.Page))
+	}
+	if pc.PerPage > 0 {
+		perPage := pc.PerPage
+		if perPage > MaxPerPage {
+			perPage = MaxPerPage
+		}
+		v.Set("per_page", strconv.Itoa(perPage))
+	}
+	return v
+}
+
+// cursorFromURL returns the page cursor of a pagination link
+func cursorFromURL(rawURL string) *PageCursor {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+	return cursorFromQuery(u.Query())
+}
+
+// cursorFromQuery returns the page parameters found in a query
+func cursorFromQuery(q url.Values) *PageCursor {
+	page, _ := strconv.Atoi(q.Get("page"))
+	perPage, _ := strconv.Atoi(q.Get("per_page"))
+	if page == 0 && perPage == 0 {
+		return nil
+	}
+	return &PageCursor{Page: page, PerPage: perPage}
+}
+
+// pageValues returns the page parameters for a list request.
+// The cursor takes precedence, otherwise only the page parameters of a
+// raw query, such as one returned by HasNextPage, are kept so that any
+// filters it repeats are not sent twice.
+func pageValues(cursor *PageCursor, rawQuery string) url.Values {
+	if cursor == nil && rawQuery != "" {
+		q, _ := url.ParseQuery(rawQuery)
+		cursor = cursorFromQuery(q)
+	}
+	return cursor.values()
+}
+
+// withCursor returns a filter that merges the cursor into the query of the
+// given filter, replacing any page parameters the filter already has
+func withCursor(filter QueryFilter, cursor *PageCursor) QueryFilter {
+	if cursor == nil {
+		return filter
+	}
+	return &cursorFilter{filter: filter, cursor: cursor}
+}
+
+type cursorFilter struct {
+	filter QueryFilter
+	cursor *PageCursor
+}
+
+// QueryString satisfies the QueryFilter interface
+func (cf *cursorFilter) QueryString() string {
+	q := url.Values{}
+	if cf.filter != nil {
+		q, _ = url.ParseQuery(cf.filter.QueryString())
+	}
+
+	q.Del("page")
+	q.Del("per_page")
+	for k, v := range cf.cursor.values() {
+		q[k] = v
+	}
+
+	return q.Encode()
+}
+
+// Link is a single link parsed from a Link header
+type Link struct {
+	URL    string
+	Rel    []string
+	Params map[string]string
+}
+
+// HasRel reports whether the link has the given relation type
+func (l Link) HasRel(rel string) bool {
+	for _, r := range l.Rel {
+		if strings.EqualFold(r, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseLinkHeader parses the values of a Link header as described in
+// RFC 8288, for example:
+// <https://example.freshservice.com/api/v2/tickets?page=2>; rel="next", <...?page=9>; rel="last"
+// Malformed links are skipped.
+func ParseLinkHeader(values ...string) []Link {
+	var links []Link
+	for _, v := range values {
+		for _, raw := range splitOutsideQuotes(v, ',') {
+			if link, ok := parseLink(raw); ok {
+				links = append(links, link)
+			}
+		}
+	}
+	return links
+}
+
+// parseLink parses a single link-value
+func parseLink(raw string) (Link, bool) {
+	raw = strings.TrimSpace(raw)
+	if !strings.HasPrefix(raw, "<") {
+		return Link{}, false
+	}
+
+	end := strings.Index(raw, ">")
+	if end < 0 {
+		return Link{}, false
+	}
+
+	link := Link{URL: strings.TrimSpace(raw[1:end]), Params: map[string]string{}}
+	for _, param := range splitOutsideQuotes(raw[end+1:], ';') {
+		param = strings.TrimSpace(param)
+		if param == "" {
+			continue
+		}
+
+		key, value := param, ""
+		if i := strings.Index(param, "="); i >= 0 {
+			key, value = strings.TrimSpace(param[:i]), strings.TrimSpace(param[i+1:])
+		}
+		key = strings.ToLower(key)
+		value = unquote(value)
+
+		// only the first occurrence of a parameter is used
+		if _, ok := link.Params[key]; ok {
+			continue
+		}
+		link.Params[key] = value
+
+		if key == "rel" {
+			link.Rel = strings.Fields(value)
+		}
+	}
+
+	return link, true
+}
+
+// splitOutsideQuotes splits s on sep, ignoring separators inside quoted
+// strings and angle brackets
+func splitOutsideQuotes(s string, sep byte) []string {
+	var parts []string
+	var inQuotes, inURL, escaped bool
+	start := 0
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\' && inQuotes:
+			escaped = true
+		case c == '"' && !inURL:
+			inQuotes = !inQuotes
+		case c == '<' && !inQuotes:
+			inURL = true
+		case c == '>' && !inQuotes:
+			inURL = false
+		case c == sep && !inQuotes && !inURL:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+// unquote removes the quotes and escapes of a quoted-string
+func unquote(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+
+	s = s[1 : len(s)-1]
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// Pagination holds the links to other pages of a list
+type Pagination struct {
+	Next  *PageCursor
+	Prev  *PageCursor
+	First *PageCursor
+	Last  *PageCursor
+
+	// NextURL is the full URL of the next page
+	NextURL string
+}
+
+// parsePagination resolves the pagination links of a response
+func parsePagination(res *http.Response) Pagination {
+	var p Pagination
+	for _, link := range ParseLinkHeader(res.Header["Link"]...) {
+		target := link.URL
+		if res.Request != nil {
+			if u, err := res.Request.URL.Parse(link.URL); err == nil {
+				target = u.String()
+			}
+		}
+
+		// a single link may carry several relation types
+		cursor := cursorFromURL(target)
+		if link.HasRel("next") {
+			p.Next, p.NextURL = cursor, target
+		}
+		if link.HasRel("prev") || link.HasRel("previous") {
+			p.Prev = cursor
+		}
+		if link.HasRel("first") {
+			p.First = cursor
+		}
+		if link.HasRel("last") {
+			p.Last = cursor
+		}
+	}
+	return p
+}
@@ -0,0 +1,43 @@
+package freshservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	links := ParseLinkHeader(
+		`<https://domain.freshservice.com/api/v2/tickets?page=3&per_page=30>; rel="next", <https://domain.freshservice.com/api/v2/tickets?page=1&per_page=30>; rel="prev first"`,
+		`<https://domain.freshservice.com/api/v2/tickets?filter=a,b&page=9>; rel=last; title="last, page"`,
+		`not a link`,
+	)
+
+	assert.Len(t, links, 3)
+	assert.Equal(t, "https://domain.freshservice.com/api/v2/tickets?page=3&per_page=30", links[0].URL)
+	assert.True(t, links[0].HasRel("next"))
+	assert.Equal(t, []string{"prev", "first"}, links[1].Rel)
+	assert.True(t, links[1].HasRel("FIRST"))
+	assert.Equal(t, "https://domain.freshservice.com/api/v2/tickets?filter=a,b&page=9", links[2].URL)
+	assert.True(t, links[2].HasRel("last"))
+	assert.Equal(t, "last, page", links[2].Params["title"])
+}
+
+func TestPageCursorMerge(t *testing.T) {
+	opts := &TicketListOptions{
+		PageQuery: "filter=watching&page=4&per_page=50",
+		SortBy:    &SortOptions{Descending: true},
+	}
+
+	// only the page parameters of the raw page query are kept
+	assert.Equal(t, "order_type=desc&page=4&per_page=50", opts.QueryString())
+
+	opts.Page = &PageCursor{Page: 2, PerPage: 500}
+	assert.Equal(t, "order_type=desc&page=2&per_page=100", opts.QueryString())
+
+	merged := withCursor(&AgentListFilter{Active: true, Page: &PageCursor{Page: 1}}, &PageCursor{Page: 7})
+	assert.Equal(t, "active=true&page=7", merged.QueryString())
+
+	assert.Equal(t, "page=3", withCursor(nil, &PageCursor{Page: 3}).QueryString())
+	assert.Equal(t, "", (&PageCursor{}).QueryString())
+}
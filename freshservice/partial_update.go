@@ -0,0 +1,55 @@
+package freshservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// marshalUpdate encodes an update request type, whose fields are all
+// omitted when unset, adding an explicit null for every field listed
+// in null. Listing a field that does not exist or is also set is an error.
+func marshalUpdate(v interface{}, null []string) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(null) == 0 {
+		return b, nil
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(v))
+	for _, name := range null {
+		if !known[name] {
+			return nil, fmt.Errorf("cannot clear unknown field %q", name)
+		}
+		if _, ok := fields[name]; ok {
+			return nil, fmt.Errorf("field %q is both set and cleared", name)
+		}
+		fields[name] = json.RawMessage("null")
+	}
+
+	return json.Marshal(fields)
+}
+
+// jsonFieldNames returns the JSON names of the fields of a struct type
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,53 @@
+package freshservice_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/CoreyGriffin/go-freshservice/freshservice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTicketUpdateFields(t *testing.T) {
+	var body string
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v2/tickets/12", r.URL.Path)
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		fmt.Fprint(w, `{"ticket":{"id":12,"status":4}}`)
+	})
+	defer server.Close()
+
+	ticket, err := c.Tickets().UpdateFields(context.Background(), 12, &freshservice.TicketUpdate{
+		Status:       freshservice.Int(freshservice.TicketResolved),
+		CustomFields: freshservice.CustomFields{"root_cause": nil},
+		Null:         []string{"responder_id"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, freshservice.TicketResolved, ticket.Status)
+	assert.JSONEq(t, `{"status":4,"responder_id":null,"custom_fields":{"root_cause":null}}`, body)
+}
+
+func TestUpdateMarshal(t *testing.T) {
+	due := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	b, err := json.Marshal(&freshservice.TaskUpdate{DueDate: &due, Title: freshservice.String("")})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"due_date":"2021-03-04T05:06:07Z","title":""}`, string(b))
+
+	b, err = json.Marshal(&freshservice.AgentUpdate{Occasional: freshservice.Bool(false), Null: []string{"reporting_manager_id", "location_id"}})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"occasional":false,"reporting_manager_id":null,"location_id":null}`, string(b))
+
+	_, err = json.Marshal(&freshservice.TicketUpdate{Null: []string{"not_a_field"}})
+	assert.NotNil(t, err)
+
+	_, err = json.Marshal(&freshservice.TicketUpdate{GroupID: freshservice.Int(1), Null: []string{"group_id"}})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,170 @@
+package freshservice
+
+import (
+	"context"
+	"sync"
+)
+
+const defaultPrefetchConcurrency = 4
+
+// PrefetchOptions controls how the pages of a list are fetched concurrently
+type PrefetchOptions struct {
+	// Concurrency is the maximum number of pages requested at the same
+	// time, defaulting to 4. Every request still waits on the client's
+	// CreditLimiter, if set, so it also bounds the credits spent.
+	Concurrency int
+	// PerPage is the number of items requested per page,
+	// defaulting to MaxPerPage
+	PerPage int
+	// Pages is the total number of pages when it is already known.
+	// Otherwise it is taken from the rel="last" link of the first page
+	// and, when Freshservice does not return one, the remaining pages
+	// are fetched one at a time following the rel="next" links.
+	Pages int
+}
+
+// withDefaults returns a copy of the options with the defaults applied
+func (o *PrefetchOptions) withDefaults() PrefetchOptions {
+	var opts PrefetchOptions
+	if o != nil {
+		opts = *o
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultPrefetchConcurrency
+	}
+	if opts.PerPage <= 0 || opts.PerPage > MaxPerPage {
+		opts.PerPage = MaxPerPage
+	}
+	return opts
+}
+
+// pageFunc requests a single page with the given filter
+type pageFunc func(context.Context, QueryFilter) (interface{}, *Response, error)
+
+// emitFunc delivers a page to the typed pages channel, returning false
+// if the context is done before the page is received
+type emitFunc func(ctx context.Context, page int, items interface{}) bool
+
+type pageResult struct {
+	page  int
+	items interface{}
+	err   error
+}
+
+// prefetcher holds the state shared by every typed prefetcher. Pages
+// are requested by a bounded number of goroutines and delivered in page
+// order. The first error or the context being done aborts every
+// request in flight.
+type prefetcher struct {
+	err error
+}
+
+// Err returns the error that stopped prefetching, it must only be
+// called once the pages channel has been closed
+func (p *prefetcher) Err() error {
+	return p.err
+}
+
+// run fetches every page and delivers them with emit, calling done once
+// every request has returned
+func (p *prefetcher) run(ctx context.Context, filter QueryFilter, o *PrefetchOptions, fetch pageFunc, emit emitFunc, done func()) {
+	defer done()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	opts := o.withDefaults()
+
+	items, resp, err := fetch(ctx, withCursor(filter, &PageCursor{Page: 1, PerPage: opts.PerPage}))
+	if err != nil {
+		p.err = err
+		return
+	}
+	if !emit(ctx, 1, items) {
+		p.err = ctx.Err()
+		return
+	}
+
+	last := opts.Pages
+	if last == 0 && resp.Last != nil {
+		last = resp.Last.Page
+	}
+
+	if last == 0 {
+		p.err = p.follow(ctx, filter, opts, resp, fetch, emit)
+		return
+	}
+
+	p.err = p.fetchRange(ctx, filter, opts, last, fetch, emit)
+}
+
+// follow fetches the remaining pages one at a time when the total
+// number of pages is unknown
+func (p *prefetcher) follow(ctx context.Context, filter QueryFilter, opts PrefetchOptions, resp *Response, fetch pageFunc, emit emitFunc) error {
+	for page := 2; resp.Next != nil; page++ {
+		cursor := *resp.Next
+		if cursor.PerPage == 0 {
+			cursor.PerPage = opts.PerPage
+		}
+
+		var items interface{}
+		var err error
+		items, resp, err = fetch(ctx, withCursor(filter, &cursor))
+		if err != nil {
+			return err
+		}
+		if !emit(ctx, page, items) {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// fetchRange fetches pages 2 to last concurrently, delivering them in order
+func (p *prefetcher) fetchRange(ctx context.Context, filter QueryFilter, opts PrefetchOptions, last int, fetch pageFunc, emit emitFunc) error {
+	// cancel any requests in flight before waiting for them to return
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the page being delivered plus the buffered ones are in flight
+	pending := make(chan chan pageResult, opts.Concurrency-1)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(pending)
+		for page := 2; page <= last; page++ {
+			result := make(chan pageResult, 1)
+			select {
+			case pending <- result:
+			case <-ctx.Done():
+				return
+			}
+
+			wg.Add(1)
+			go func(page int) {
+				defer wg.Done()
+				items, _, err := fetch(ctx, withCursor(filter, &PageCursor{Page: page, PerPage: opts.PerPage}))
+				result <- pageResult{page: page, items: items, err: err}
+			}(page)
+		}
+	}()
+
+	for result := range pending {
+		r := <-result
+		if r.err != nil {
+			return r.err
+		}
+		if !emit(ctx, r.page, r.items) {
+			return ctx.Err()
+		}
+	}
+
+	return ctx.Err()
+}
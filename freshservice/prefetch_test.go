@@ -0,0 +1,123 @@
+package freshservice_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/CoreyGriffin/go-freshservice/freshservice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTicketPrefetchOrdered(t *testing.T) {
+	const pages = 8
+	var inFlight, maxInFlight int32
+	var mu sync.Mutex
+	var queries []string
+
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mu.Unlock()
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 1 {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next", <%s?page=%d>; rel="last"`, r.URL.Path, r.URL.Path, pages))
+		}
+
+		// later pages respond first to check the delivery order
+		time.Sleep(time.Duration(pages-page) * 5 * time.Millisecond)
+		fmt.Fprintf(w, `{"tickets":[{"id":%d}]}`, page)
+	})
+	defer server.Close()
+
+	p := c.Tickets().Prefetch(context.Background(), &freshservice.TicketListOptions{SortBy: &freshservice.SortOptions{Ascending: true}}, &freshservice.PrefetchOptions{Concurrency: 3, PerPage: 1})
+
+	var got []int
+	for page := range p.Pages() {
+		assert.Len(t, page.Tickets, 1)
+		assert.Equal(t, page.Page, page.Tickets[0].ID)
+		got = append(got, page.Page)
+	}
+
+	assert.Nil(t, p.Err())
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, got)
+	assert.True(t, atomic.LoadInt32(&maxInFlight) <= 3)
+	assert.Contains(t, queries, "order_type=asc&page=5&per_page=1")
+}
+
+func TestAgentPrefetchFollowsNextLinks(t *testing.T) {
+	var queries []string
+	server, c := newPagedServer(t, 3, &queries)
+	defer server.Close()
+
+	p := c.Agents().Prefetch(context.Background(), &freshservice.AgentListFilter{Active: true}, nil)
+
+	var got []int
+	for page := range p.Pages() {
+		got = append(got, page.Page)
+	}
+
+	assert.Nil(t, p.Err())
+	assert.Equal(t, []int{1, 2, 3}, got)
+	assert.Equal(t, []string{"active=true&page=1&per_page=100", "active=true&page=2&per_page=2", "active=true&page=3&per_page=2"}, queries)
+}
+
+func TestAssetPrefetchError(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		switch {
+		case page == 3:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case page > 3:
+			// aborted when page 3 fails
+			<-r.Context().Done()
+			return
+		}
+		fmt.Fprintf(w, `{"assets":[{"id":%d}]}`, page)
+	})
+	defer server.Close()
+
+	p := c.Assets().Prefetch(context.Background(), nil, &freshservice.PrefetchOptions{Pages: 10})
+
+	var got []int
+	for page := range p.Pages() {
+		got = append(got, page.Page)
+	}
+
+	assert.Equal(t, []int{1, 2}, got)
+	assert.True(t, errors.Is(p.Err(), freshservice.ErrNotFound))
+}
+
+func TestApplicationPrefetchCancelled(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"applications":[{"id":1}]}`)
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := c.Applications().Prefetch(ctx, nil, &freshservice.PrefetchOptions{Pages: 100})
+
+	<-p.Pages()
+	cancel()
+	for range p.Pages() {
+	}
+
+	assert.True(t, errors.Is(p.Err(), context.Canceled))
+}
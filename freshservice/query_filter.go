@@ -1,8 +1,28 @@
 package freshservice
 
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
 // QueryFilter is an interface that can be passed around
 // to Freshservice API methods that can accept a query param filter
 type QueryFilter interface {
 	// QueryString should take return string with the query parameters attached
 	QueryString() string
 }
+
+// queryTime formats a timestamp for a query parameter. Freshservice
+// expects RFC3339 timestamps, they are always sent in UTC.
+func queryTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// setList sets a parameter to the comma separated values,
+// leaving it unset when there are none
+func setList(v url.Values, key string, values []string) {
+	if len(values) > 0 {
+		v.Set(key, strings.Join(values, ","))
+	}
+}
@@ -0,0 +1,68 @@
+package freshservice_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CoreyGriffin/go-freshservice/freshservice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListQueryStrings(t *testing.T) {
+	since := time.Date(2020, 5, 1, 23, 30, 0, 0, time.FixedZone("PDT", -7*3600))
+
+	tests := []struct {
+		filter freshservice.QueryFilter
+		want   string
+	}{
+		{&freshservice.TicketListOptions{}, ""},
+		{
+			&freshservice.TicketListOptions{
+				Page: &freshservice.PageCursor{Page: 2, PerPage: 50},
+				FilterBy: &freshservice.TicketFilter{
+					Watching:       true,
+					Deleted:        true,
+					RequesterID:    freshservice.Int(9),
+					RequesterEmail: freshservice.String("a+b@x.com"),
+					UpdatedSince:   &since,
+					Type:           freshservice.String("Service Request"),
+				},
+				SortBy: &freshservice.SortOptions{OrderBy: "due_by", Ascending: true},
+				Embed:  &freshservice.TicketEmbedOptions{Stats: true, RequesterInfo: true},
+			},
+			"email=a%2Bb%40x.com&filter=watching&include=requester%2Cstats&order_by=due_by&order_type=asc" +
+				"&page=2&per_page=50&requester_id=9&type=Service+Request&updated_since=2020-05-02T06%3A30%3A00Z",
+		},
+		{
+			&freshservice.TicketListOptions{SortBy: &freshservice.SortOptions{}, Embed: &freshservice.TicketEmbedOptions{Stats: true}},
+			"include=stats&order_type=desc",
+		},
+		{
+			&freshservice.AgentListFilter{
+				Email:       freshservice.String("o'neil+it@x.com"),
+				MobilePhone: freshservice.Int(5551234),
+				WorkPhone:   freshservice.Int(5554321),
+				Active:      true,
+				Fulltime:    true,
+			},
+			"active=true&email=o%27neil%2Bit%40x.com&mobile_phone_number=5551234&state=fulltime&work_phone_number=5554321",
+		},
+		{&freshservice.AgentListFilter{Occasional: true, PageQuery: "email=x&page=3"}, "page=3&state=occasional"},
+		{&freshservice.AgentListFilter{Fulltime: true, Occasional: true}, ""},
+		{
+			&freshservice.AssetListOptions{
+				SortBy: &freshservice.SortOptions{OrderBy: "created_at", Descending: true},
+				Embed:  &freshservice.AssetEmbedOptions{TypeFields: true, Trashed: true},
+			},
+			"include=type_fields&order_by=created_at&order_type=desc&trashed=true",
+		},
+		{&freshservice.ApplicationListOptions{Page: &freshservice.PageCursor{Page: 4}}, "page=4"},
+		{&freshservice.AnnouncementListFilter{State: "active", Page: &freshservice.PageCursor{PerPage: 10}}, "per_page=10&state=active"},
+		{&freshservice.AnnouncementListFilter{}, ""},
+		{&freshservice.ServiceCatalogItemListFilter{CatalogID: 12}, "category_id=12"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.filter.QueryString())
+	}
+}
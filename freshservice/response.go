@@ -0,0 +1,54 @@
+package freshservice
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Response wraps the HTTP response returned by the Freshservice API
+// and exposes the metadata parsed from its headers
+type Response struct {
+	*http.Response
+
+	// RateLimit holds the API credit usage reported by Freshservice
+	RateLimit RateLimit
+	// Pagination holds the cursors of the next, previous, first and last
+	// pages of a list. Next is nil on the last page.
+	Pagination
+	// RequestID is the ID assigned to the request by Freshservice
+	RequestID string
+}
+
+// RateLimit holds the API credit usage returned in the
+// X-RateLimit-* headers of every response
+type RateLimit struct {
+	// Total number of credits available per minute
+	Total int
+	// Remaining number of credits for the current minute
+	Remaining int
+	// UsedCurrentRequest is the number of credits consumed by this request
+	UsedCurrentRequest int
+}
+
+// newResponse parses the metadata out of a HTTP response
+func newResponse(res *http.Response) *Response {
+	r := &Response{
+		Response:  res,
+		RequestID: res.Header.Get("X-Request-Id"),
+		RateLimit: RateLimit{
+			Total:              headerInt(res.Header, "X-Ratelimit-Total"),
+			Remaining:          headerInt(res.Header, "X-Ratelimit-Remaining"),
+			UsedCurrentRequest: headerInt(res.Header, "X-Ratelimit-Used-Currentrequest"),
+		},
+	}
+
+	r.Pagination = parsePagination(res)
+
+	return r
+}
+
+// headerInt returns the integer value of a header, or 0 if it is missing
+func headerInt(h http.Header, key string) int {
+	v, _ := strconv.Atoi(h.Get(key))
+	return v
+}
@@ -0,0 +1,60 @@
+package freshservice_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/CoreyGriffin/go-freshservice/freshservice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListWithResponse(t *testing.T) {
+	var serverURL string
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.Header().Set("X-RateLimit-Total", "200")
+		w.Header().Set("X-RateLimit-Remaining", "197")
+		w.Header().Set("X-RateLimit-Used-CurrentRequest", "3")
+		w.Header().Set("Link", fmt.Sprintf(`<%s/api/v2/tickets?include=stats&page=3>; rel="next"`, serverURL))
+		fmt.Fprint(w, `{"tickets":[{"id":1},{"id":2}]}`)
+	})
+	defer server.Close()
+	serverURL = server.URL
+
+	tickets, resp, err := c.Tickets().ListWithResponse(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Len(t, tickets, 2)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "req-123", resp.RequestID)
+	assert.Equal(t, freshservice.RateLimit{Total: 200, Remaining: 197, UsedCurrentRequest: 3}, resp.RateLimit)
+	assert.Equal(t, server.URL+"/api/v2/tickets?include=stats&page=3", resp.NextURL)
+	assert.Equal(t, &freshservice.PageCursor{Page: 3}, resp.Next)
+	assert.Nil(t, resp.Last)
+
+	_, next, err := c.Tickets().List(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "include=stats&page=3", next)
+}
+
+func TestResponseOnError(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-429")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer server.Close()
+
+	ad, resp, err := c.Agents().GetWithResponse(context.Background(), 1)
+	assert.Nil(t, ad)
+	assert.True(t, errors.Is(err, freshservice.ErrRateLimited))
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, 0, resp.RateLimit.Remaining)
+	assert.Nil(t, resp.Next)
+
+	var apiErr *freshservice.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "req-429", apiErr.RequestID)
+}
@@ -0,0 +1,166 @@
+package freshservice
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryAttempts   = 4
+	defaultRetryMinBackoff = time.Second
+	defaultRetryMaxBackoff = time.Minute
+)
+
+// RetryPolicy controls how the client retries requests that fail with a
+// rate limit (HTTP 429), a server error (HTTP 5xx) or a transport error.
+// A nil policy on the Client disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request,
+	// including the first one. Values less than 2 disable retries.
+	MaxAttempts int
+	// MinBackoff is the base delay used for the exponential backoff
+	MinBackoff time.Duration
+	// MaxBackoff caps the computed backoff delay. A Retry-After header
+	// returned by Freshservice is always honored, even if it is longer.
+	MaxBackoff time.Duration
+	// RetryNonIdempotent allows POST and PATCH requests to be retried.
+	// Leave disabled unless duplicate resources are acceptable.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a retry policy suitable for most API consumers
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: defaultRetryAttempts,
+		MinBackoff:  defaultRetryMinBackoff,
+		MaxBackoff:  defaultRetryMaxBackoff,
+	}
+}
+
+// shouldRetry reports whether another attempt should be made
+// for a request given the outcome of the previous attempt
+func (p *RetryPolicy) shouldRetry(r *http.Request, res *http.Response, err error, attempt int) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+
+	if !p.RetryNonIdempotent && !isIdempotent(r.Method) {
+		return false
+	}
+
+	// the body has already been consumed and cannot be sent again
+	if r.Body != nil && r.Body != http.NoBody && r.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		// the caller gave up, there is no point in trying again
+		return r.Context().Err() == nil
+	}
+
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError
+}
+
+// backoff returns how long to wait before the next attempt. The
+// Retry-After header takes precedence over the exponential backoff
+// which uses full jitter to avoid synchronised retries across clients.
+func (p *RetryPolicy) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	min := p.MinBackoff
+	if min <= 0 {
+		min = defaultRetryMinBackoff
+	}
+
+	max := p.MaxBackoff
+	if max <= 0 {
+		max = defaultRetryMaxBackoff
+	}
+
+	ceiling := float64(min) * math.Pow(2, float64(attempt-1))
+	if ceiling > float64(max) {
+		ceiling = float64(max)
+	}
+
+	return time.Duration(rand.Int63n(int64(ceiling)) + 1)
+}
+
+// parseRetryAfter handles both forms of the Retry-After header,
+// delay in seconds and a HTTP date
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// isIdempotent reports whether a HTTP method can safely be repeated
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// rewindRequest returns a copy of the request with a fresh body
+// so that it can be sent again
+func rewindRequest(r *http.Request) (*http.Request, error) {
+	next := r.Clone(r.Context())
+	if r.GetBody == nil {
+		return next, nil
+	}
+
+	body, err := r.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	next.Body = body
+
+	return next, nil
+}
+
+// sleepContext waits for the given duration unless the context is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// discardBody drains and closes a response body that will not be decoded
+func discardBody(body io.ReadCloser) {
+	io.Copy(ioutil.Discard, io.LimitReader(body, maxDrainBytes))
+	body.Close()
+}
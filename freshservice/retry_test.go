@@ -0,0 +1,56 @@
+package freshservice
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	wait, ok := parseRetryAfter("30")
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, wait)
+
+	wait, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 5, MinBackoff: time.Second, MaxBackoff: 4 * time.Second}
+
+	for attempt := 1; attempt <= 5; attempt++ {
+		wait := p.backoff(attempt, nil)
+		assert.True(t, wait > 0)
+		assert.True(t, wait <= 4*time.Second)
+	}
+
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"10"}}}
+	assert.Equal(t, 10*time.Second, p.backoff(1, res))
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	p := DefaultRetryPolicy()
+	get, _ := http.NewRequest(http.MethodGet, "https://domain.freshservice.com/api/v2/tickets", nil)
+	post, _ := http.NewRequest(http.MethodPost, "https://domain.freshservice.com/api/v2/tickets", nil)
+
+	assert.True(t, p.shouldRetry(get, &http.Response{StatusCode: http.StatusTooManyRequests}, nil, 1))
+	assert.True(t, p.shouldRetry(get, &http.Response{StatusCode: http.StatusBadGateway}, nil, 1))
+	assert.False(t, p.shouldRetry(get, &http.Response{StatusCode: http.StatusBadRequest}, nil, 1))
+	assert.False(t, p.shouldRetry(get, &http.Response{StatusCode: http.StatusTooManyRequests}, nil, p.MaxAttempts))
+	assert.False(t, p.shouldRetry(post, &http.Response{StatusCode: http.StatusTooManyRequests}, nil, 1))
+
+	p.RetryNonIdempotent = true
+	assert.True(t, p.shouldRetry(post, &http.Response{StatusCode: http.StatusTooManyRequests}, nil, 1))
+
+	var disabled *RetryPolicy
+	assert.False(t, disabled.shouldRetry(get, &http.Response{StatusCode: http.StatusTooManyRequests}, nil, 1))
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
 )
 
 const (
@@ -16,8 +15,15 @@ const (
 // the service catalog endpoints of the Freshservice API
 type ServiceCatalogService interface {
 	List(context.Context, QueryFilter) ([]ServiceCatalogItemDetails, error)
+	ListWithResponse(context.Context, QueryFilter) ([]ServiceCatalogItemDetails, *Response, error)
+	ListAll(context.Context, QueryFilter) ([]ServiceCatalogItemDetails, error)
+	Iter(context.Context, QueryFilter) *ServiceCatalogItemIterator
 	Categories(context.Context) ([]ServiceCategory, error)
+	CategoriesWithResponse(context.Context) ([]ServiceCategory, *Response, error)
 	Get(context.Context, int) (*ServiceCatalogItemDetails, error)
+	GetWithResponse(context.Context, int) (*ServiceCatalogItemDetails, *Response, error)
+	PlaceRequest(context.Context, int, *PlaceRequestOptions) (*TicketDetails, error)
+	PlaceRequestWithResponse(context.Context, int, *PlaceRequestOptions) (*TicketDetails, *Response, error)
 }
 
 // ServiceCatalogServiceClient facilitates requests with the ServiceCatalogService methods
@@ -28,67 +34,145 @@ type ServiceCatalogServiceClient struct {
 // List all service category items in Freshservice
 // Optional filter: category_id=[category_id]
 func (sc *ServiceCatalogServiceClient) List(ctx context.Context, filter QueryFilter) ([]ServiceCatalogItemDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   sc.client.Domain,
-		Path:   serviceCatalogItemURL,
-	}
+	list, _, err := sc.ListWithResponse(ctx, filter)
+	return list, err
+}
 
-	if filter != nil {
-		url.RawQuery = filter.QueryString()
+// ListWithResponse is the same as List but also returns the Freshservice API response
+func (sc *ServiceCatalogServiceClient) ListWithResponse(ctx context.Context, filter QueryFilter) ([]ServiceCatalogItemDetails, *Response, error) {
+	req, err := sc.client.newRequest(ctx, http.MethodGet, serviceCatalogItemURL, filter, nil)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+	res := &ServiceCatalog{}
+	resp, err := sc.client.makeRequest(req, res)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	res := &ServiceCatalog{}
-	if _, err := sc.client.makeRequest(req, res); err != nil {
-		return nil, err
+	return res.Items, resp, nil
+}
+
+// Iter returns an iterator over every service catalog item matching the filter,
+// following the pagination links returned by Freshservice
+func (sc *ServiceCatalogServiceClient) Iter(ctx context.Context, filter QueryFilter) *ServiceCatalogItemIterator {
+	it := &ServiceCatalogItemIterator{}
+	it.pager = newPager(ctx, filter, func(ctx context.Context, f QueryFilter) (*Response, error) {
+		list, resp, err := sc.ListWithResponse(ctx, f)
+		it.page = list
+		return resp, err
+	})
+	return it
+}
+
+// ListAll returns every service catalog item matching the filter across all pages
+func (sc *ServiceCatalogServiceClient) ListAll(ctx context.Context, filter QueryFilter) ([]ServiceCatalogItemDetails, error) {
+	var all []ServiceCatalogItemDetails
+	it := sc.Iter(ctx, filter)
+	for it.Next() {
+		all = append(all, it.Value())
 	}
+	return all, it.Err()
+}
+
+// ServiceCatalogItemIterator iterates over Freshservice service
+// catalog items page by page
+type ServiceCatalogItemIterator struct {
+	pager
+	page []ServiceCatalogItemDetails
+	cur  ServiceCatalogItemDetails
+}
+
+// Next advances the iterator to the next service catalog item. It returns
+// false when there are none left or an error occurred.
+func (it *ServiceCatalogItemIterator) Next() bool {
+	for len(it.page) == 0 {
+		if !it.fetchNext() {
+			return false
+		}
+	}
+
+	if !it.take() {
+		return false
+	}
+
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
 
-	return res.Items, nil
+// Value returns the current service catalog item
+func (it *ServiceCatalogItemIterator) Value() ServiceCatalogItemDetails {
+	return it.cur
 }
 
 // Categories will list all service catalog item categories in freshservice
 func (sc *ServiceCatalogServiceClient) Categories(ctx context.Context) ([]ServiceCategory, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   sc.client.Domain,
-		Path:   serviceCatalogCategoryURL,
-	}
+	list, _, err := sc.CategoriesWithResponse(ctx)
+	return list, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+// CategoriesWithResponse is the same as Categories but also returns the Freshservice API response
+func (sc *ServiceCatalogServiceClient) CategoriesWithResponse(ctx context.Context) ([]ServiceCategory, *Response, error) {
+	req, err := sc.client.newRequest(ctx, http.MethodGet, serviceCatalogCategoryURL, nil, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &ServiceCategories{}
-	if _, err := sc.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := sc.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return res.List, nil
+	return res.List, resp, nil
 }
 
 // Get a specific service category item from Freshservice via the item's ID
 func (sc *ServiceCatalogServiceClient) Get(ctx context.Context, id int) (*ServiceCatalogItemDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   sc.client.Domain,
-		Path:   fmt.Sprintf("%s/%d", serviceCatalogItemURL, id),
-	}
+	details, _, err := sc.GetWithResponse(ctx, id)
+	return details, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+// GetWithResponse is the same as Get but also returns the Freshservice API response
+func (sc *ServiceCatalogServiceClient) GetWithResponse(ctx context.Context, id int) (*ServiceCatalogItemDetails, *Response, error) {
+	req, err := sc.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%d", serviceCatalogItemURL, id), nil, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &ServiceCatalogItem{}
-	if _, err := sc.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := sc.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &res.Details, resp, nil
+}
+
+// PlaceRequest orders a service catalog item by its ID, returning the
+// service request ticket created for it
+func (sc *ServiceCatalogServiceClient) PlaceRequest(ctx context.Context, id int, opts *PlaceRequestOptions) (*TicketDetails, error) {
+	details, _, err := sc.PlaceRequestWithResponse(ctx, id, opts)
+	return details, err
+}
+
+// PlaceRequestWithResponse is the same as PlaceRequest but also returns the Freshservice API response
+func (sc *ServiceCatalogServiceClient) PlaceRequestWithResponse(ctx context.Context, id int, opts *PlaceRequestOptions) (*TicketDetails, *Response, error) {
+	if opts == nil {
+		opts = &PlaceRequestOptions{}
+	}
+
+	req, err := sc.client.newRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%d/place_request", serviceCatalogItemURL, id), nil, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := &ServiceRequest{}
+	resp, err := sc.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return &res.Details, nil
+	return &res.Details, resp, nil
 }
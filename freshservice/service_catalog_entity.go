@@ -1,7 +1,7 @@
 package freshservice
 
 import (
-	"fmt"
+	"strconv"
 	"time"
 )
 
@@ -47,6 +47,80 @@ type ServiceCatalogItemDetails struct {
 	ChildItems             []interface{}     `json:"child_items"`
 }
 
+// PlaceRequestOptions are the details of a service catalog item request.
+// Without a requester the request is placed for the user of the API key.
+type PlaceRequestOptions struct {
+	// Quantity of the item to request, Freshservice defaults to 1
+	Quantity int `json:"quantity,omitempty"`
+	// RequestedFor is the email of the user the item is requested for
+	RequestedFor string `json:"requested_for,omitempty"`
+	// Email of the requester placing the request
+	Email string `json:"email,omitempty"`
+	// CustomFields holds the answers to the item's custom fields
+	CustomFields CustomFields `json:"custom_fields,omitempty"`
+	// ChildItems are the child items of a bundle to request with the item
+	ChildItems []ChildItemRequest `json:"child_items,omitempty"`
+}
+
+// ChildItemRequest is a child item requested with a service catalog bundle
+type ChildItemRequest struct {
+	ServiceItemID int `json:"service_item_id"`
+	Quantity      int `json:"quantity,omitempty"`
+}
+
+// ServiceRequest holds the service request ticket created by placing a request
+type ServiceRequest struct {
+	Details TicketDetails `json:"service_request"`
+}
+
+const (
+	// RequestedItemRequested is the stage of an item that has been requested
+	RequestedItemRequested = 1
+	// RequestedItemDelivered is the stage of an item that has been delivered
+	RequestedItemDelivered = 2
+	// RequestedItemCancelled is the stage of an item whose request was cancelled
+	RequestedItemCancelled = 3
+	// RequestedItemFulfilled is the stage of an item that has been fulfilled
+	RequestedItemFulfilled = 4
+	// RequestedItemPartiallyFulfilled is the stage of an item that has been partially fulfilled
+	RequestedItemPartiallyFulfilled = 5
+)
+
+// RequestedItems holds the items requested on a service request
+type RequestedItems struct {
+	List []RequestedItemDetails `json:"requested_items"`
+}
+
+// RequestedItem holds a specific item requested on a service request
+type RequestedItem struct {
+	Details RequestedItemDetails `json:"requested_item"`
+}
+
+// RequestedItemDetails are the details of a service catalog item requested on a service request
+type RequestedItemDetails struct {
+	ID             int          `json:"id"`
+	ServiceItemID  int          `json:"service_item_id"`
+	Quantity       int          `json:"quantity"`
+	Stage          int          `json:"stage"`
+	Loaned         bool         `json:"loaned"`
+	CostPerRequest float64      `json:"cost_per_request"`
+	Remarks        string       `json:"remarks"`
+	DeliveryTime   int          `json:"delivery_time"`
+	IsParent       bool         `json:"is_parent"`
+	CustomFields   CustomFields `json:"custom_fields"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+// RequestedItemUpdate holds the changes made to a requested item,
+// only the fields that are set are sent
+type RequestedItemUpdate struct {
+	Stage        *int         `json:"stage,omitempty"`
+	Quantity     *int         `json:"quantity,omitempty"`
+	Remarks      *string      `json:"remarks,omitempty"`
+	CustomFields CustomFields `json:"custom_fields,omitempty"`
+}
+
 // ServiceCategories represents service catalog item categories in Freshservice
 type ServiceCategories struct {
 	List []ServiceCategory `json:"service_categories"`
@@ -66,9 +140,15 @@ type ServiceCategory struct {
 // for a service catalog API list request
 type ServiceCatalogItemListFilter struct {
 	CatalogID int
+	// Page selects the page and page size to return
+	Page *PageCursor
 }
 
 // QueryString allows the available filter items to meet the QueryFilter interface
 func (scf *ServiceCatalogItemListFilter) QueryString() string {
-	return fmt.Sprintf("category_id=%d", scf.CatalogID)
+	v := scf.Page.values()
+	if scf.CatalogID != 0 {
+		v.Set("category_id", strconv.Itoa(scf.CatalogID))
+	}
+	return v.Encode()
 }
@@ -0,0 +1,44 @@
+package freshservice_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/CoreyGriffin/go-freshservice/freshservice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceCatalogPlaceRequest(t *testing.T) {
+	var body map[string]interface{}
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v2/service_catalog/items/21/place_request", r.URL.Path)
+		body = nil
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		fmt.Fprint(w, `{"service_request":{"id":77,"type":"Service Request","subject":"Request for : Laptop"}}`)
+	})
+	defer server.Close()
+
+	ticket, err := c.ServiceCatalog().PlaceRequest(context.Background(), 21, &freshservice.PlaceRequestOptions{
+		Quantity:     2,
+		RequestedFor: "new.hire@example.com",
+		CustomFields: freshservice.CustomFields{"operating_system": "Linux"},
+		ChildItems:   []freshservice.ChildItemRequest{{ServiceItemID: 22, Quantity: 1}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 77, ticket.ID)
+	assert.Equal(t, "Service Request", ticket.Type)
+	assert.Equal(t, map[string]interface{}{
+		"quantity":      float64(2),
+		"requested_for": "new.hire@example.com",
+		"custom_fields": map[string]interface{}{"operating_system": "Linux"},
+		"child_items":   []interface{}{map[string]interface{}{"service_item_id": float64(22), "quantity": float64(1)}},
+	}, body)
+
+	_, err = c.ServiceCatalog().PlaceRequest(context.Background(), 21, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{}, body)
+}
@@ -1,12 +1,9 @@
 package freshservice
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
 )
 
 /*
@@ -17,10 +14,19 @@ NOTE: The tasks methods are related to the ticket methods and use the ticket end
 // the task endpoints of the Freshservice API
 type TaskService interface {
 	List(context.Context, int) ([]TaskDetails, error)
+	ListWithResponse(context.Context, int) ([]TaskDetails, *Response, error)
+	ListAll(context.Context, int) ([]TaskDetails, error)
+	Iter(context.Context, int) *TaskIterator
 	Create(context.Context, int, *TaskDetails) (*TaskDetails, error)
+	CreateWithResponse(context.Context, int, *TaskDetails) (*TaskDetails, *Response, error)
 	Get(context.Context, int, int) (*TaskDetails, error)
+	GetWithResponse(context.Context, int, int) (*TaskDetails, *Response, error)
 	Update(context.Context, int, int, *TaskDetails) (*TaskDetails, error)
+	UpdateWithResponse(context.Context, int, int, *TaskDetails) (*TaskDetails, *Response, error)
+	UpdateFields(context.Context, int, int, *TaskUpdate) (*TaskDetails, error)
+	UpdateFieldsWithResponse(context.Context, int, int, *TaskUpdate) (*TaskDetails, *Response, error)
 	Delete(context.Context, int, int) error
+	DeleteWithResponse(context.Context, int, int) (*Response, error)
 }
 
 // TaskServiceClient facilitates requests with the TicketService methods
@@ -30,122 +36,185 @@ type TaskServiceClient struct {
 
 // List all tasks assigned to a given ticket ID
 func (c *TaskServiceClient) List(ctx context.Context, tickID int) ([]TaskDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   c.client.Domain,
-		Path:   fmt.Sprintf("%s/%d/tasks", ticketURL, tickID),
-	}
+	list, _, err := c.ListWithResponse(ctx, tickID)
+	return list, err
+}
+
+// ListWithResponse is the same as List but also returns the Freshservice API response
+func (c *TaskServiceClient) ListWithResponse(ctx context.Context, tickID int) ([]TaskDetails, *Response, error) {
+	return c.list(ctx, tickID, nil)
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+// list requests a page of tasks for a given ticket ID
+func (c *TaskServiceClient) list(ctx context.Context, tickID int, filter QueryFilter) ([]TaskDetails, *Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%d/tasks", ticketURL, tickID), filter, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &Tasks{}
-	_, err = c.client.makeRequest(req, res)
+	resp, err := c.client.makeRequest(req, res)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
+	}
+
+	return res.List, resp, nil
+}
+
+// Iter returns an iterator over every task assigned to a given ticket ID,
+// following the pagination links returned by Freshservice
+func (c *TaskServiceClient) Iter(ctx context.Context, tickID int) *TaskIterator {
+	it := &TaskIterator{}
+	it.pager = newPager(ctx, nil, func(ctx context.Context, f QueryFilter) (*Response, error) {
+		list, resp, err := c.list(ctx, tickID, f)
+		it.page = list
+		return resp, err
+	})
+	return it
+}
+
+// ListAll returns every task assigned to a given ticket ID across all pages
+func (c *TaskServiceClient) ListAll(ctx context.Context, tickID int) ([]TaskDetails, error) {
+	var all []TaskDetails
+	it := c.Iter(ctx, tickID)
+	for it.Next() {
+		all = append(all, it.Value())
 	}
+	return all, it.Err()
+}
 
-	return res.List, nil
+// TaskIterator iterates over Freshservice tasks page by page
+type TaskIterator struct {
+	pager
+	page []TaskDetails
+	cur  TaskDetails
+}
+
+// Next advances the iterator to the next task. It returns
+// false when there are none left or an error occurred.
+func (it *TaskIterator) Next() bool {
+	for len(it.page) == 0 {
+		if !it.fetchNext() {
+			return false
+		}
+	}
+
+	if !it.take() {
+		return false
+	}
+
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current task
+func (it *TaskIterator) Value() TaskDetails {
+	return it.cur
 }
 
 // Get a specific task assigned to a given ticket ID
 func (c *TaskServiceClient) Get(ctx context.Context, tickID int, tid int) (*TaskDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   c.client.Domain,
-		Path:   fmt.Sprintf("%s/%d/tasks/%d", ticketURL, tickID, tid),
-	}
+	details, _, err := c.GetWithResponse(ctx, tickID, tid)
+	return details, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+// GetWithResponse is the same as Get but also returns the Freshservice API response
+func (c *TaskServiceClient) GetWithResponse(ctx context.Context, tickID int, tid int) (*TaskDetails, *Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%d/tasks/%d", ticketURL, tickID, tid), nil, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &Task{}
-	_, err = c.client.makeRequest(req, res)
+	resp, err := c.client.makeRequest(req, res)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
-	return &res.Details, nil
+
+	return &res.Details, resp, nil
 }
 
 // Create a task on a given ticket by ID
 func (c *TaskServiceClient) Create(ctx context.Context, tickID int, td *TaskDetails) (*TaskDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   c.client.Domain,
-		Path:   fmt.Sprintf("%s/%d/tasks", ticketURL, tickID),
-	}
-
-	taskContent, err := json.Marshal(td)
-	if err != nil {
-		return nil, err
-	}
-
-	body := bytes.NewReader(taskContent)
+	details, _, err := c.CreateWithResponse(ctx, tickID, td)
+	return details, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url.String(), body)
+// CreateWithResponse is the same as Create but also returns the Freshservice API response
+func (c *TaskServiceClient) CreateWithResponse(ctx context.Context, tickID int, td *TaskDetails) (*TaskDetails, *Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%d/tasks", ticketURL, tickID), nil, td)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &Task{}
-	_, err = c.client.makeRequest(req, res)
+	resp, err := c.client.makeRequest(req, res)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
-	return &res.Details, nil
+
+	return &res.Details, resp, nil
 }
 
-// Update a specific task for a given ticket ID
+// Update a specific task for a given ticket ID. Every field is sent, use UpdateFields
+// to change only some of them.
 func (c *TaskServiceClient) Update(ctx context.Context, tickID int, tid int, td *TaskDetails) (*TaskDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   c.client.Domain,
-		Path:   fmt.Sprintf("%s/%d/tasks/%d", ticketURL, tickID, tid),
+	details, _, err := c.UpdateWithResponse(ctx, tickID, tid, td)
+	return details, err
+}
+
+// UpdateWithResponse is the same as Update but also returns the Freshservice API response
+func (c *TaskServiceClient) UpdateWithResponse(ctx context.Context, tickID int, tid int, td *TaskDetails) (*TaskDetails, *Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%d/tasks/%d", ticketURL, tickID, tid), nil, td)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	taskContent, err := json.Marshal(td)
+	res := &Task{}
+	resp, err := c.client.makeRequest(req, res)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
-	body := bytes.NewReader(taskContent)
+	return &res.Details, resp, nil
+}
+
+// UpdateFields sends only the fields set on the update, leaving the rest
+// of the task unchanged, unlike Update which sends every field
+func (c *TaskServiceClient) UpdateFields(ctx context.Context, tickID int, tid int, u *TaskUpdate) (*TaskDetails, error) {
+	details, _, err := c.UpdateFieldsWithResponse(ctx, tickID, tid, u)
+	return details, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url.String(), body)
+// UpdateFieldsWithResponse is the same as UpdateFields but also returns the Freshservice API response
+func (c *TaskServiceClient) UpdateFieldsWithResponse(ctx context.Context, tickID int, tid int, u *TaskUpdate) (*TaskDetails, *Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%d/tasks/%d", ticketURL, tickID, tid), nil, u)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &Task{}
-	_, err = c.client.makeRequest(req, res)
+	resp, err := c.client.makeRequest(req, res)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
-	return &res.Details, nil
+
+	return &res.Details, resp, nil
 }
 
 // Delete a specific task for a given ticket ID
 // Note: Deleted tasks are permanently lost. You can't retrieve them once it's get deleted.
 func (c *TaskServiceClient) Delete(ctx context.Context, tickID int, tid int) error {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   c.client.Domain,
-		Path:   fmt.Sprintf("%s/%d/tasks/%d", ticketURL, tickID, tid),
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url.String(), nil)
-	if err != nil {
-		return err
-	}
+	_, err := c.DeleteWithResponse(ctx, tickID, tid)
+	return err
+}
 
-	res := &Task{}
-	_, err = c.client.makeRequest(req, res)
+// DeleteWithResponse is the same as Delete but also returns the Freshservice API response
+func (c *TaskServiceClient) DeleteWithResponse(ctx context.Context, tickID int, tid int) (*Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%d/tasks/%d", ticketURL, tickID, tid), nil, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return c.client.makeRequest(req, nil)
 }
@@ -26,3 +26,23 @@ type TaskDetails struct {
 	ClosedAt     int       `json:"closed_at"`
 	GroupID      int       `json:"group_id"`
 }
+
+// TaskUpdate holds the changes made to a task with UpdateFields.
+// Only the fields that are set are sent, list the JSON names of fields
+// to clear, such as "agent_id", in Null.
+type TaskUpdate struct {
+	AgentID      *int       `json:"agent_id,omitempty"`
+	GroupID      *int       `json:"group_id,omitempty"`
+	Status       *int       `json:"status,omitempty"`
+	DueDate      *time.Time `json:"due_date,omitempty"`
+	NotifyBefore *int       `json:"notify_before,omitempty"`
+	Title        *string    `json:"title,omitempty"`
+	Description  *string    `json:"description,omitempty"`
+	Null         []string   `json:"-"`
+}
+
+// MarshalJSON encodes the fields that are set and those cleared with Null
+func (u *TaskUpdate) MarshalJSON() ([]byte, error) {
+	type update TaskUpdate
+	return marshalUpdate((*update)(u), u.Null)
+}
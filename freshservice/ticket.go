@@ -1,7 +1,6 @@
 package freshservice
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -9,17 +8,53 @@ import (
 	"net/url"
 )
 
-const ticketURL = "/api/v2/tickets"
+const (
+	ticketURL = "/api/v2/tickets"
+	// defaultFilterPerPage is the page size of filter
+	// requests that do not set one
+	defaultFilterPerPage = 30
+)
 
 // TicketService is an interface for interacting with
 // the ticket endpoints of the Freshservice API
 type TicketService interface {
 	List(context.Context, QueryFilter) ([]TicketDetails, string, error)
+	ListWithResponse(context.Context, QueryFilter) ([]TicketDetails, *Response, error)
+	ListAll(context.Context, QueryFilter) ([]TicketDetails, error)
+	Iter(context.Context, QueryFilter) *TicketIterator
+	Prefetch(context.Context, QueryFilter, *PrefetchOptions) *TicketPrefetcher
+	Filter(context.Context, *Query, *FilterOptions) (*FilteredTickets, error)
+	FilterWithResponse(context.Context, *Query, *FilterOptions) (*FilteredTickets, *Response, error)
+	FilterIter(context.Context, *Query) *TicketIterator
+	IterResumable(context.Context, string, *TicketListOptions, CheckpointStore) (*ResumableTicketIterator, error)
 	Create(context.Context, *TicketDetails) (*TicketDetails, error)
-	CreateWithAttachment() (*Ticket, error)
+	CreateWithResponse(context.Context, *TicketDetails) (*TicketDetails, *Response, error)
+	CreateWithAttachment(context.Context, *TicketDetails, []AttachmentFile, *UploadOptions) (*TicketDetails, error)
+	CreateWithAttachmentWithResponse(context.Context, *TicketDetails, []AttachmentFile, *UploadOptions) (*TicketDetails, *Response, error)
 	Get(context.Context, int, QueryFilter) (*TicketDetails, error)
+	GetWithResponse(context.Context, int, QueryFilter) (*TicketDetails, *Response, error)
 	Update(context.Context, int, *TicketDetails) (*TicketDetails, error)
+	UpdateWithResponse(context.Context, int, *TicketDetails) (*TicketDetails, *Response, error)
+	UpdateFields(context.Context, int, *TicketUpdate) (*TicketDetails, error)
+	UpdateFieldsWithResponse(context.Context, int, *TicketUpdate) (*TicketDetails, *Response, error)
+	UpdateWithAttachment(context.Context, int, *TicketDetails, []AttachmentFile, *UploadOptions) (*TicketDetails, error)
+	UpdateWithAttachmentWithResponse(context.Context, int, *TicketDetails, []AttachmentFile, *UploadOptions) (*TicketDetails, *Response, error)
 	Delete(context.Context, int) error
+	DeleteWithResponse(context.Context, int) (*Response, error)
+	Restore(context.Context, int) error
+	RestoreWithResponse(context.Context, int) (*Response, error)
+	DeletePermanently(context.Context, int) error
+	DeletePermanentlyWithResponse(context.Context, int) (*Response, error)
+	SetSpam(context.Context, int, bool) (*TicketDetails, error)
+	SetSpamWithResponse(context.Context, int, bool) (*TicketDetails, *Response, error)
+	ListAllDeleted(context.Context, *TicketListOptions) ([]TicketDetails, error)
+	IterDeleted(context.Context, *TicketListOptions) *TicketIterator
+	ListAllSpam(context.Context, *TicketListOptions) ([]TicketDetails, error)
+	IterSpam(context.Context, *TicketListOptions) *TicketIterator
+	RequestedItems(context.Context, int) ([]RequestedItemDetails, error)
+	RequestedItemsWithResponse(context.Context, int) ([]RequestedItemDetails, *Response, error)
+	UpdateRequestedItem(context.Context, int, int, *RequestedItemUpdate) (*RequestedItemDetails, error)
+	UpdateRequestedItemWithResponse(context.Context, int, int, *RequestedItemUpdate) (*RequestedItemDetails, *Response, error)
 }
 
 // TicketServiceClient facilitates requests with the TicketService methods
@@ -31,132 +66,562 @@ type TicketServiceClient struct {
 // All the below requests are paginated to return only 30 tickets per page.
 // Append the parameter "page=[:page_no]" in the url to traverse through pages.
 func (t *TicketServiceClient) List(ctx context.Context, filter QueryFilter) ([]TicketDetails, string, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   t.client.Domain,
-		Path:   ticketURL,
+	list, resp, err := t.ListWithResponse(ctx, filter)
+	if err != nil {
+		return nil, "", err
 	}
 
-	if filter != nil {
-		url.RawQuery = filter.QueryString()
-	}
+	return list, HasNextPage(resp.Response), nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+// ListWithResponse is the same as List but also returns the Freshservice API response
+func (t *TicketServiceClient) ListWithResponse(ctx context.Context, filter QueryFilter) ([]TicketDetails, *Response, error) {
+	req, err := t.client.newRequest(ctx, http.MethodGet, ticketURL, filter, nil)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	res := &Tickets{}
 	resp, err := t.client.makeRequest(req, res)
 	if err != nil {
-		return nil, "", err
+		return nil, resp, err
 	}
 
-	return res.List, HasNextPage(resp), nil
+	return res.List, resp, nil
 }
 
-// Create a new Freshservice ticket
-func (t *TicketServiceClient) Create(ctx context.Context, td *TicketDetails) (*TicketDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   t.client.Domain,
-		Path:   ticketURL,
+// Iter returns an iterator over every ticket matching the filter,
+// following the pagination links returned by Freshservice
+func (t *TicketServiceClient) Iter(ctx context.Context, filter QueryFilter) *TicketIterator {
+	it := &TicketIterator{}
+	it.pager = newPager(ctx, filter, func(ctx context.Context, f QueryFilter) (*Response, error) {
+		list, resp, err := t.ListWithResponse(ctx, f)
+		it.page = list
+		return resp, err
+	})
+	return it
+}
+
+// ListAll returns every ticket matching the filter across all pages
+func (t *TicketServiceClient) ListAll(ctx context.Context, filter QueryFilter) ([]TicketDetails, error) {
+	var all []TicketDetails
+	it := t.Iter(ctx, filter)
+	for it.Next() {
+		all = append(all, it.Value())
+	}
+	return all, it.Err()
+}
+
+// TicketIterator iterates over Freshservice tickets page by page
+type TicketIterator struct {
+	pager
+	page []TicketDetails
+	cur  TicketDetails
+}
+
+// Next advances the iterator to the next ticket. It returns
+// false when there are none left or an error occurred.
+func (it *TicketIterator) Next() bool {
+	for len(it.page) == 0 {
+		if !it.fetchNext() {
+			return false
+		}
+	}
+
+	if !it.take() {
+		return false
+	}
+
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current ticket
+func (it *TicketIterator) Value() TicketDetails {
+	return it.cur
+}
+
+// Filter returns a page of the tickets matching a filter query built with Q.
+// The query is validated before it is sent, returning an error matching
+// ErrInvalidQuery if Freshservice would reject it.
+func (t *TicketServiceClient) Filter(ctx context.Context, q *Query, opts *FilterOptions) (*FilteredTickets, error) {
+	res, _, err := t.FilterWithResponse(ctx, q, opts)
+	return res, err
+}
+
+// FilterWithResponse is the same as Filter but also returns the Freshservice API response
+func (t *TicketServiceClient) FilterWithResponse(ctx context.Context, q *Query, opts *FilterOptions) (*FilteredTickets, *Response, error) {
+	if err := q.Validate(); err != nil {
+		return nil, nil, err
 	}
 
-	ticketContent, err := json.Marshal(td)
+	fq := &filterQuery{query: q}
+	if opts != nil {
+		fq.page = opts.Page
+	}
+	return t.filter(ctx, fq)
+}
+
+// filter requests a page of tickets matching a filter query
+func (t *TicketServiceClient) filter(ctx context.Context, filter QueryFilter) (*FilteredTickets, *Response, error) {
+	req, err := t.client.newRequest(ctx, http.MethodGet, ticketURL+"/filter", filter, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	res := &FilteredTickets{}
+	resp, err := t.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return res, resp, nil
+}
+
+// FilterIter returns an iterator over every ticket matching a filter query.
+// Freshservice reports the total number of matching tickets rather than
+// linking to the next page, so pages are requested until it is reached.
+func (t *TicketServiceClient) FilterIter(ctx context.Context, q *Query) *TicketIterator {
+	it := &TicketIterator{}
+	it.pager = newPager(ctx, &filterQuery{query: q}, func(ctx context.Context, f QueryFilter) (*Response, error) {
+		if err := q.Validate(); err != nil {
+			return nil, err
+		}
+
+		res, resp, err := t.filter(ctx, f)
+		if err != nil {
+			return resp, err
+		}
+		it.page = res.List
+
+		if resp.Next == nil {
+			resp.Next = nextFilterPage(f, len(res.List), res.Total)
+		}
+		return resp, nil
+	})
+	return it
+}
+
+// nextFilterPage returns the page following the one requested with
+// filter, or nil if every matching ticket has been returned
+func nextFilterPage(filter QueryFilter, count int, total int) *PageCursor {
+	q, _ := url.ParseQuery(filter.QueryString())
+	cur := cursorFromQuery(q)
+	if cur == nil {
+		cur = &PageCursor{}
+	}
+	if cur.Page == 0 {
+		cur.Page = 1
+	}
+	if cur.PerPage == 0 {
+		cur.PerPage = defaultFilterPerPage
 	}
 
-	body := bytes.NewReader(ticketContent)
+	if count == 0 || cur.Page*cur.PerPage >= total {
+		return nil
+	}
+	return &PageCursor{Page: cur.Page + 1, PerPage: cur.PerPage}
+}
+
+// Prefetch requests the pages of tickets matching the filter concurrently
+// and delivers them in page order through the Pages channel
+func (t *TicketServiceClient) Prefetch(ctx context.Context, filter QueryFilter, opts *PrefetchOptions) *TicketPrefetcher {
+	p := &TicketPrefetcher{pages: make(chan TicketPage)}
+	fetch := func(ctx context.Context, f QueryFilter) (interface{}, *Response, error) {
+		return t.ListWithResponse(ctx, f)
+	}
+	emit := func(ctx context.Context, page int, items interface{}) bool {
+		select {
+		case p.pages <- TicketPage{Page: page, Tickets: items.([]TicketDetails)}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	go p.run(ctx, filter, opts, fetch, emit, func() { close(p.pages) })
+	return p
+}
+
+// TicketPage is a single page of tickets delivered by a TicketPrefetcher
+type TicketPage struct {
+	Page    int
+	Tickets []TicketDetails
+}
+
+// TicketPrefetcher fetches pages of tickets concurrently
+type TicketPrefetcher struct {
+	prefetcher
+	pages chan TicketPage
+}
+
+// Pages returns the channel the pages are delivered on in page order. It
+// is closed once every page has been delivered or prefetching stopped,
+// after which Err reports why. Cancel the context to stop early.
+func (p *TicketPrefetcher) Pages() <-chan TicketPage {
+	return p.pages
+}
+
+// IterResumable returns an iterator over every ticket matching opts that
+// saves a checkpoint to the store under key after each page. If a
+// checkpoint was already saved under key the traversal resumes from it
+// with the saved options, in which case opts may be nil. Resuming with
+// different options returns ErrCheckpointMismatch.
+func (t *TicketServiceClient) IterResumable(ctx context.Context, key string, opts *TicketListOptions, store CheckpointStore) (*ResumableTicketIterator, error) {
+	var filter QueryFilter
+	if opts != nil {
+		base := *opts
+		base.Page, base.PageQuery = nil, ""
+		filter = &base
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url.String(), body)
+	it := &ResumableTicketIterator{}
+	r, saved, err := newResumer(ctx, store, key, filter)
 	if err != nil {
 		return nil, err
 	}
 
+	if saved != nil && len(saved.Filter) > 0 && string(saved.Filter) != "null" {
+		resumed := &TicketListOptions{}
+		if err := json.Unmarshal(saved.Filter, resumed); err != nil {
+			return nil, fmt.Errorf("error decoding checkpoint filter: %w", err)
+		}
+		r.filter = resumed
+	}
+
+	r.fetch = func(ctx context.Context, f QueryFilter) (*Response, error) {
+		list, resp, err := t.ListWithResponse(ctx, f)
+		it.page = list
+		return resp, err
+	}
+	it.resumer = r
+
+	return it, nil
+}
+
+// ResumableTicketIterator iterates over Freshservice tickets page by
+// page, saving its progress so it can be resumed. Tickets that shift
+// onto a page already checkpointed, because earlier tickets were deleted
+// while iterating or while stopped, are missed.
+type ResumableTicketIterator struct {
+	resumer
+	page []TicketDetails
+	cur  TicketDetails
+}
+
+// Next advances the iterator to the next ticket, skipping tickets that
+// were already returned. It returns false when there are none left or
+// an error occurred.
+func (it *ResumableTicketIterator) Next() bool {
+	for {
+		for len(it.page) > 0 {
+			td := it.page[0]
+			it.page = it.page[1:]
+			if !it.seen(td.ID) {
+				it.cur = td
+				if len(it.page) == 0 {
+					it.consumed()
+				}
+				return true
+			}
+		}
+
+		if !it.fetchNext() {
+			return false
+		}
+	}
+}
+
+// Value returns the current ticket
+func (it *ResumableTicketIterator) Value() TicketDetails {
+	return it.cur
+}
+
+// Create a new Freshservice ticket
+func (t *TicketServiceClient) Create(ctx context.Context, td *TicketDetails) (*TicketDetails, error) {
+	details, _, err := t.CreateWithResponse(ctx, td)
+	return details, err
+}
+
+// CreateWithResponse is the same as Create but also returns the Freshservice API response
+func (t *TicketServiceClient) CreateWithResponse(ctx context.Context, td *TicketDetails) (*TicketDetails, *Response, error) {
+	req, err := t.client.newRequest(ctx, http.MethodPost, ticketURL, nil, td)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	res := &Ticket{}
-	if _, err := t.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := t.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return &res.Details, nil
+	return &res.Details, resp, nil
 }
 
-// CreateWithAttachment creates new Freshservice ticket with attachment
-func (t *TicketServiceClient) CreateWithAttachment() (*Ticket, error) {
-	return nil, nil
+// CreateWithAttachment creates a new Freshservice ticket with attachments.
+// The ticket and files are sent as a streamed multipart/form-data body,
+// empty text and number fields are not sent.
+func (t *TicketServiceClient) CreateWithAttachment(ctx context.Context, td *TicketDetails, files []AttachmentFile, opts *UploadOptions) (*TicketDetails, error) {
+	details, _, err := t.CreateWithAttachmentWithResponse(ctx, td, files, opts)
+	return details, err
+}
+
+// CreateWithAttachmentWithResponse is the same as CreateWithAttachment but also returns the Freshservice API response
+func (t *TicketServiceClient) CreateWithAttachmentWithResponse(ctx context.Context, td *TicketDetails, files []AttachmentFile, opts *UploadOptions) (*TicketDetails, *Response, error) {
+	req, err := t.client.newMultipartRequest(ctx, http.MethodPost, ticketURL, td, files, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := &Ticket{}
+	resp, err := t.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &res.Details, resp, nil
 }
 
 // Get a specific Freshservice ticket by Ticket ID. By default, certain
 // fields such as conversations, tags and requester email will not be included
-// in the response. They can be retrieved via the embedding functionality.
+// in the response. They can be retrieved by passing TicketGetOptions.
 func (t *TicketServiceClient) Get(ctx context.Context, id int, filter QueryFilter) (*TicketDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   t.client.Domain,
-		Path:   fmt.Sprintf("%s/%d", ticketURL, id),
+	details, _, err := t.GetWithResponse(ctx, id, filter)
+	return details, err
+}
+
+// GetWithResponse is the same as Get but also returns the Freshservice API response
+func (t *TicketServiceClient) GetWithResponse(ctx context.Context, id int, filter QueryFilter) (*TicketDetails, *Response, error) {
+	req, err := t.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%d", ticketURL, id), filter, nil)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if filter != nil {
-		url.RawQuery = filter.QueryString()
+	res := &Ticket{}
+	resp, err := t.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+	return &res.Details, resp, nil
+}
+
+// Update a Freshservice ticket. Every field is sent, use UpdateFields
+// to change only some of them.
+func (t *TicketServiceClient) Update(ctx context.Context, id int, details *TicketDetails) (*TicketDetails, error) {
+	details, _, err := t.UpdateWithResponse(ctx, id, details)
+	return details, err
+}
+
+// UpdateWithResponse is the same as Update but also returns the Freshservice API response
+func (t *TicketServiceClient) UpdateWithResponse(ctx context.Context, id int, details *TicketDetails) (*TicketDetails, *Response, error) {
+	req, err := t.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%d", ticketURL, id), nil, details)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res := &Ticket{}
-	if _, err := t.client.makeRequest(req, res); err != nil {
-		return nil, err
+	resp, err := t.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
-	return &res.Details, nil
+
+	return &res.Details, resp, nil
 }
 
-// Update a Freshservice ticket
-func (t *TicketServiceClient) Update(ctx context.Context, id int, details *TicketDetails) (*TicketDetails, error) {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   t.client.Domain,
-		Path:   fmt.Sprintf("%s/%d", ticketURL, id),
+// UpdateWithAttachment updates a Freshservice ticket, adding attachments.
+// Empty text and number fields are not sent.
+func (t *TicketServiceClient) UpdateWithAttachment(ctx context.Context, id int, details *TicketDetails, files []AttachmentFile, opts *UploadOptions) (*TicketDetails, error) {
+	details, _, err := t.UpdateWithAttachmentWithResponse(ctx, id, details, files, opts)
+	return details, err
+}
+
+// UpdateWithAttachmentWithResponse is the same as UpdateWithAttachment but also returns the Freshservice API response
+func (t *TicketServiceClient) UpdateWithAttachmentWithResponse(ctx context.Context, id int, details *TicketDetails, files []AttachmentFile, opts *UploadOptions) (*TicketDetails, *Response, error) {
+	req, err := t.client.newMultipartRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%d", ticketURL, id), details, files, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := &Ticket{}
+	resp, err := t.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &res.Details, resp, nil
+}
+
+// UpdateFields sends only the fields set on the update, leaving the rest
+// of the ticket unchanged, unlike Update which sends every field
+func (t *TicketServiceClient) UpdateFields(ctx context.Context, id int, u *TicketUpdate) (*TicketDetails, error) {
+	details, _, err := t.UpdateFieldsWithResponse(ctx, id, u)
+	return details, err
+}
+
+// UpdateFieldsWithResponse is the same as UpdateFields but also returns the Freshservice API response
+func (t *TicketServiceClient) UpdateFieldsWithResponse(ctx context.Context, id int, u *TicketUpdate) (*TicketDetails, *Response, error) {
+	req, err := t.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%d", ticketURL, id), nil, u)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	ticketContent, err := json.Marshal(details)
+	res := &Ticket{}
+	resp, err := t.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &res.Details, resp, nil
+}
+
+// Delete Freshservice ticket. The ticket is moved to the trash, it
+// can be restored with Restore or removed with DeletePermanently.
+func (t *TicketServiceClient) Delete(ctx context.Context, id int) error {
+	_, err := t.DeleteWithResponse(ctx, id)
+	return err
+}
+
+// DeleteWithResponse is the same as Delete but also returns the Freshservice API response
+func (t *TicketServiceClient) DeleteWithResponse(ctx context.Context, id int) (*Response, error) {
+	req, err := t.client.newRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", ticketURL, id), nil, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	body := bytes.NewReader(ticketContent)
+	return t.client.makeRequest(req, nil)
+}
+
+// Restore a deleted Freshservice ticket from the trash
+func (t *TicketServiceClient) Restore(ctx context.Context, id int) error {
+	_, err := t.RestoreWithResponse(ctx, id)
+	return err
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url.String(), body)
+// RestoreWithResponse is the same as Restore but also returns the Freshservice API response
+func (t *TicketServiceClient) RestoreWithResponse(ctx context.Context, id int) (*Response, error) {
+	req, err := t.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%d/restore", ticketURL, id), nil, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	res := &Ticket{}
-	if _, err := t.client.makeRequest(req, res); err != nil {
+	return t.client.makeRequest(req, nil)
+}
+
+// DeletePermanently removes a ticket that is in the trash or marked as spam.
+// Note: Permanently deleted tickets cannot be restored.
+func (t *TicketServiceClient) DeletePermanently(ctx context.Context, id int) error {
+	_, err := t.DeletePermanentlyWithResponse(ctx, id)
+	return err
+}
+
+// DeletePermanentlyWithResponse is the same as DeletePermanently but also returns the Freshservice API response
+func (t *TicketServiceClient) DeletePermanentlyWithResponse(ctx context.Context, id int) (*Response, error) {
+	req, err := t.client.newRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%d/delete_forever", ticketURL, id), nil, nil)
+	if err != nil {
 		return nil, err
 	}
-	return &res.Details, nil
+
+	return t.client.makeRequest(req, nil)
 }
 
-// Delete Freshservice ticket
-func (t *TicketServiceClient) Delete(ctx context.Context, id int) error {
-	url := &url.URL{
-		Scheme: "https",
-		Host:   t.client.Domain,
-		Path:   fmt.Sprintf("%s/%d", ticketURL, id),
+// SetSpam marks a ticket as spam, or returns a ticket marked as spam to
+// the ticket list when spam is false
+func (t *TicketServiceClient) SetSpam(ctx context.Context, id int, spam bool) (*TicketDetails, error) {
+	details, _, err := t.SetSpamWithResponse(ctx, id, spam)
+	return details, err
+}
+
+// SetSpamWithResponse is the same as SetSpam but also returns the Freshservice API response
+func (t *TicketServiceClient) SetSpamWithResponse(ctx context.Context, id int, spam bool) (*TicketDetails, *Response, error) {
+	return t.UpdateFieldsWithResponse(ctx, id, &TicketUpdate{Spam: &spam})
+}
+
+// ListAllDeleted returns every ticket in the trash matching the other
+// options across all pages
+func (t *TicketServiceClient) ListAllDeleted(ctx context.Context, opts *TicketListOptions) ([]TicketDetails, error) {
+	return t.ListAll(ctx, withPredefinedFilter(opts, TicketFilter{Deleted: true}))
+}
+
+// IterDeleted returns an iterator over the tickets in the trash
+// matching the other options
+func (t *TicketServiceClient) IterDeleted(ctx context.Context, opts *TicketListOptions) *TicketIterator {
+	return t.Iter(ctx, withPredefinedFilter(opts, TicketFilter{Deleted: true}))
+}
+
+// ListAllSpam returns every ticket marked as spam matching the other
+// options across all pages
+func (t *TicketServiceClient) ListAllSpam(ctx context.Context, opts *TicketListOptions) ([]TicketDetails, error) {
+	return t.ListAll(ctx, withPredefinedFilter(opts, TicketFilter{Spam: true}))
+}
+
+// IterSpam returns an iterator over the tickets marked as spam
+// matching the other options
+func (t *TicketServiceClient) IterSpam(ctx context.Context, opts *TicketListOptions) *TicketIterator {
+	return t.Iter(ctx, withPredefinedFilter(opts, TicketFilter{Spam: true}))
+}
+
+// withPredefinedFilter returns a copy of the list options using the
+// predefined filter set on f in place of any predefined filter they
+// have, keeping all their other filters
+func withPredefinedFilter(opts *TicketListOptions, f TicketFilter) *TicketListOptions {
+	o := TicketListOptions{}
+	if opts != nil {
+		o = *opts
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url.String(), nil)
+	if o.FilterBy != nil {
+		f.RequesterID = o.FilterBy.RequesterID
+		f.RequesterEmail = o.FilterBy.RequesterEmail
+		f.UpdatedSince = o.FilterBy.UpdatedSince
+		f.Type = o.FilterBy.Type
+	}
+	o.FilterBy = &f
+	return &o
+}
+
+// RequestedItems lists the service catalog items requested on a given
+// service request ticket ID
+func (t *TicketServiceClient) RequestedItems(ctx context.Context, id int) ([]RequestedItemDetails, error) {
+	list, _, err := t.RequestedItemsWithResponse(ctx, id)
+	return list, err
+}
+
+// RequestedItemsWithResponse is the same as RequestedItems but also returns the Freshservice API response
+func (t *TicketServiceClient) RequestedItemsWithResponse(ctx context.Context, id int) ([]RequestedItemDetails, *Response, error) {
+	req, err := t.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%d/requested_items", ticketURL, id), nil, nil)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	if _, err := t.client.makeRequest(req, nil); err != nil {
-		return err
+	res := &RequestedItems{}
+	resp, err := t.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return res.List, resp, nil
+}
+
+// UpdateRequestedItem updates an item requested on a given service request
+// ticket ID, for example to move it to the RequestedItemDelivered stage
+func (t *TicketServiceClient) UpdateRequestedItem(ctx context.Context, id int, itemID int, u *RequestedItemUpdate) (*RequestedItemDetails, error) {
+	details, _, err := t.UpdateRequestedItemWithResponse(ctx, id, itemID, u)
+	return details, err
+}
+
+// UpdateRequestedItemWithResponse is the same as UpdateRequestedItem but also returns the Freshservice API response
+func (t *TicketServiceClient) UpdateRequestedItemWithResponse(ctx context.Context, id int, itemID int, u *RequestedItemUpdate) (*RequestedItemDetails, *Response, error) {
+	req, err := t.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%d/requested_items/%d", ticketURL, id, itemID), nil, u)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := &RequestedItem{}
+	resp, err := t.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
 	}
 
-	return nil
+	return &res.Details, resp, nil
 }
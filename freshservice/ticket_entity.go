@@ -1,8 +1,9 @@
 package freshservice
 
 import (
-	"fmt"
-	"strings"
+	"encoding/json"
+	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -50,6 +51,13 @@ type Tickets struct {
 	List []TicketDetails `json:"tickets"`
 }
 
+// FilteredTickets holds a page of the tickets matching a filter query
+type FilteredTickets struct {
+	List []TicketDetails `json:"tickets"`
+	// Total is the number of tickets matching the query across all pages
+	Total int `json:"total"`
+}
+
 // Ticket represents a Freshservice ticket object
 type Ticket struct {
 	Details TicketDetails `json:"ticket,omitempty"`
@@ -90,6 +98,101 @@ type TicketDetails struct {
 	ItemCategory    string       `json:"item_category"`
 	Deleted         bool         `json:"deleted"`
 	Attachments     []Attachment `json:"attachments"`
+	Tags            []string     `json:"tags,omitempty"`
+
+	// The following are only returned when embedded with TicketEmbedOptions
+	// or TicketGetOptions, and are never sent when creating or updating a ticket
+	Stats         *TicketStats          `json:"stats,omitempty"`
+	Requester     *TicketRequester      `json:"requester,omitempty"`
+	Department    *TicketDepartment     `json:"department,omitempty"`
+	Conversations []ConversationDetails `json:"conversations,omitempty"`
+	Assets        []AssetDetails        `json:"assets,omitempty"`
+}
+
+// MarshalJSON leaves out the embedded read only details, which
+// Freshservice rejects when they are sent back with an update
+func (td TicketDetails) MarshalJSON() ([]byte, error) {
+	type details TicketDetails
+	d := details(td)
+	d.Stats, d.Requester, d.Department = nil, nil, nil
+	d.Conversations, d.Assets = nil, nil
+	return json.Marshal(d)
+}
+
+// TicketStats holds the response and resolution timestamps of a ticket,
+// unset timestamps are nil
+type TicketStats struct {
+	TicketID             int        `json:"ticket_id"`
+	OpenedAt             *time.Time `json:"opened_at"`
+	GroupEscalated       bool       `json:"group_escalated"`
+	InboundCount         int        `json:"inbound_count"`
+	OutboundCount        int        `json:"outbound_count"`
+	StatusUpdatedAt      *time.Time `json:"status_updated_at"`
+	PendingSince         *time.Time `json:"pending_since"`
+	FirstAssignedAt      *time.Time `json:"first_assigned_at"`
+	AssignedAt           *time.Time `json:"assigned_at"`
+	GroupAssignedAt      *time.Time `json:"group_assigned_at"`
+	FirstRespondedAt     *time.Time `json:"first_responded_at"`
+	AgentRespondedAt     *time.Time `json:"agent_responded_at"`
+	RequesterRespondedAt *time.Time `json:"requester_responded_at"`
+	GroupRespondedAt     *time.Time `json:"group_responded_at"`
+	ResolvedAt           *time.Time `json:"resolved_at"`
+	ClosedAt             *time.Time `json:"closed_at"`
+	FirstRespTimeInSecs  int        `json:"first_resp_time_in_secs"`
+	ResolutionTimeInSecs int        `json:"resolution_time_in_secs"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+// TicketRequester holds the contact details of the requester of a ticket
+type TicketRequester struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Mobile string `json:"mobile"`
+	Phone  string `json:"phone"`
+}
+
+// TicketDepartment holds the department a ticket belongs to
+type TicketDepartment struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// TicketUpdate holds the changes made to a ticket with UpdateFields.
+// Only the fields that are set are sent, list the JSON names of fields
+// to clear, such as "group_id" or "responder_id", in Null. A custom field
+// is cleared by setting it to nil in CustomFields.
+type TicketUpdate struct {
+	Subject      *string      `json:"subject,omitempty"`
+	Description  *string      `json:"description,omitempty"`
+	RequesterID  *int         `json:"requester_id,omitempty"`
+	Email        *string      `json:"email,omitempty"`
+	Status       *int         `json:"status,omitempty"`
+	Priority     *int         `json:"priority,omitempty"`
+	Source       *int         `json:"source,omitempty"`
+	Type         *string      `json:"type,omitempty"`
+	GroupID      *int         `json:"group_id,omitempty"`
+	ResponderID  *int         `json:"responder_id,omitempty"`
+	DepartmentID *int         `json:"department_id,omitempty"`
+	Category     *string      `json:"category,omitempty"`
+	SubCategory  *string      `json:"sub_category,omitempty"`
+	ItemCategory *string      `json:"item_category,omitempty"`
+	Urgency      *int         `json:"urgency,omitempty"`
+	Impact       *int         `json:"impact,omitempty"`
+	DueBy        *time.Time   `json:"due_by,omitempty"`
+	FrDueBy      *time.Time   `json:"fr_due_by,omitempty"`
+	CcEmails     []string     `json:"cc_emails,omitempty"`
+	Tags         []string     `json:"tags,omitempty"`
+	Spam         *bool        `json:"spam,omitempty"`
+	CustomFields CustomFields `json:"custom_fields,omitempty"`
+	Null         []string     `json:"-"`
+}
+
+// MarshalJSON encodes the fields that are set and those cleared with Null
+func (u *TicketUpdate) MarshalJSON() ([]byte, error) {
+	type update TicketUpdate
+	return marshalUpdate((*update)(u), u.Null)
 }
 
 // CarbonCopy manages the emails to be copied in on a ticket
@@ -101,11 +204,16 @@ type CarbonCopy struct {
 }
 
 // TicketNote represents a note added to a Freshservice ticket
+//
+// Deprecated: notes are returned as ConversationDetails by the
+// ConversationService and created with NoteDetails.
 type TicketNote struct {
 	Details TicketNoteDetails `json:"note"`
 }
 
 // TicketNoteDetails holds the details of a note added to a Freshservice ticket
+//
+// Deprecated: use ConversationDetails and NoteDetails.
 type TicketNoteDetails struct {
 	ID           int64        `json:"id"` // Read-Only
 	UserID       int64        `json:"user_id"`
@@ -137,6 +245,10 @@ type CustomFields map[string]interface{}
 // TicketListOptions holds the available options that can be
 // passed when requesting a list of Freshservice ticketsx
 type TicketListOptions struct {
+	// Page selects the page and page size to return
+	Page *PageCursor
+	// Deprecated: PageQuery is the raw query returned by HasNextPage, only
+	// its page parameters are used. Use Page instead.
 	PageQuery string
 	FilterBy  *TicketFilter
 	SortBy    *SortOptions
@@ -151,13 +263,76 @@ type TicketEmbedOptions struct {
 	RequesterInfo bool
 }
 
+// TicketGetOptions holds the options available when getting a single ticket
+type TicketGetOptions struct {
+	Embed *TicketGetEmbedOptions
+}
+
+// TicketGetEmbedOptions will optionally embed desired metadata in a ticket response.
+// As with TicketEmbedOptions each include consumes an additional 2 credits.
+type TicketGetEmbedOptions struct {
+	Conversations bool
+	RequesterInfo bool
+	Stats         bool
+	Tags          bool
+	Department    bool
+	Assets        bool
+}
+
+// QueryString allows us to pass TicketGetOptions as a QueryFilter
+func (opts *TicketGetOptions) QueryString() string {
+	v := url.Values{}
+	if e := opts.Embed; e != nil {
+		var include []string
+		for _, embed := range []struct {
+			name string
+			set  bool
+		}{
+			{"conversations", e.Conversations},
+			{"requester", e.RequesterInfo},
+			{"stats", e.Stats},
+			{"tags", e.Tags},
+			{"department", e.Department},
+			{"assets", e.Assets},
+		} {
+			if embed.set {
+				include = append(include, embed.name)
+			}
+		}
+		setList(v, "include", include)
+	}
+	return v.Encode()
+}
+
 // SortOptions will opitionally sort the ticket list results
 type SortOptions struct {
+	// OrderBy is the field to sort by, for tickets one of created_at,
+	// updated_at, due_by or status. Freshservice sorts by created_at
+	// when it is empty.
+	OrderBy    string
 	Ascending  bool
 	Descending bool
 }
 
-// TicketFilter are optional filters that can be enabled when querying a ticket list
+// setValues adds the sort parameters to a list query, results are sorted
+// in descending order unless Ascending is set
+func (so *SortOptions) setValues(v url.Values) {
+	if so == nil {
+		return
+	}
+	if so.OrderBy != "" {
+		v.Set("order_by", so.OrderBy)
+	}
+	if so.Ascending {
+		v.Set("order_type", "asc")
+	} else {
+		v.Set("order_type", "desc")
+	}
+}
+
+// TicketFilter are optional filters that can be enabled when querying a ticket list.
+// Every filter set is applied together, except that only one of the predefined
+// NewAndMyOpen, Watching, Spam and Deleted filters, the first set in that order, is used.
 type TicketFilter struct {
 	NewAndMyOpen   bool
 	Watching       bool
@@ -172,56 +347,68 @@ type TicketFilter struct {
 // QueryString allows us to pass TicketListOptions as a QueryFilter and
 // will return a new endpoint URL with query parameters attached
 func (opts *TicketListOptions) QueryString() string {
-	var qs []string
+	v := pageValues(opts.Page, opts.PageQuery)
 
-	if opts.PageQuery != "" {
-		qs = append(qs, opts.PageQuery)
-	}
-
-	if opts.FilterBy != nil {
+	if f := opts.FilterBy; f != nil {
+		// Freshservice applies a single predefined filter, the first one
+		// set is used and can be combined with all the other filters
 		switch {
-		case opts.FilterBy.NewAndMyOpen:
-			qs = append(qs, "filter=new_and_my_open")
-		case opts.FilterBy.Watching:
-			qs = append(qs, "filter=watching")
-		case opts.FilterBy.Spam:
-			qs = append(qs, "filter=spam")
-		case opts.FilterBy.Deleted:
-			qs = append(qs, "filter=deleted")
+		case f.NewAndMyOpen:
+			v.Set("filter", "new_and_my_open")
+		case f.Watching:
+			v.Set("filter", "watching")
+		case f.Spam:
+			v.Set("filter", "spam")
+		case f.Deleted:
+			v.Set("filter", "deleted")
 		}
 
-		switch {
-		case opts.FilterBy.RequesterID != nil:
-			qs = append(qs, fmt.Sprintf("requester_id=%d", *opts.FilterBy.RequesterID))
-		case opts.FilterBy.RequesterEmail != nil:
-			qs = append(qs, fmt.Sprintf("email=%v", *opts.FilterBy.RequesterEmail))
+		if f.RequesterID != nil {
+			v.Set("requester_id", strconv.Itoa(*f.RequesterID))
 		}
-
-		if opts.FilterBy.UpdatedSince != nil {
-			qs = append(qs, fmt.Sprintf("updated_since=%v", *opts.FilterBy.UpdatedSince))
+		if f.RequesterEmail != nil {
+			v.Set("email", *f.RequesterEmail)
 		}
-
-		if opts.FilterBy.Type != nil {
-			qs = append(qs, fmt.Sprintf("type=%v", *opts.FilterBy.Type))
+		if f.UpdatedSince != nil {
+			v.Set("updated_since", queryTime(*f.UpdatedSince))
 		}
-	}
-
-	if opts.SortBy != nil {
-		if opts.SortBy.Ascending {
-			qs = append(qs, "order_type=asc")
-		} else {
-			qs = append(qs, "order_type=desc")
+		if f.Type != nil {
+			v.Set("type", *f.Type)
 		}
 	}
 
+	opts.SortBy.setValues(v)
+
 	if opts.Embed != nil {
+		var include []string
 		if opts.Embed.RequesterInfo {
-			qs = append(qs, "include=requester")
+			include = append(include, "requester")
 		}
 		if opts.Embed.Stats {
-			qs = append(qs, "include=stats")
+			include = append(include, "stats")
 		}
+		setList(v, "include", include)
 	}
 
-	return strings.Join(qs, "&")
+	return v.Encode()
+}
+
+// FilterOptions holds the options that can be passed
+// when filtering tickets with a query
+type FilterOptions struct {
+	// Page selects the page and page size to return
+	Page *PageCursor
+}
+
+// filterQuery is the QueryFilter of a ticket filter request
+type filterQuery struct {
+	query *Query
+	page  *PageCursor
+}
+
+// QueryString encodes the query enclosed in double quotes as Freshservice requires
+func (fq *filterQuery) QueryString() string {
+	v := fq.page.values()
+	v.Set("query", `"`+fq.query.String()+`"`)
+	return v.Encode()
 }
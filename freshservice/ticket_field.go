@@ -0,0 +1,54 @@
+package freshservice
+
+import (
+	"context"
+	"net/http"
+)
+
+const ticketFieldURL = "/api/v2/ticket_form_fields"
+
+// TicketFieldService is an interface for interacting with
+// the ticket form field endpoint of the Freshservice API
+type TicketFieldService interface {
+	List(context.Context) ([]TicketFieldDetails, error)
+	ListWithResponse(context.Context) ([]TicketFieldDetails, *Response, error)
+	Validate(context.Context, *TicketDetails) ([]Error, error)
+}
+
+// TicketFieldServiceClient facilitates requests with the TicketFieldService methods
+type TicketFieldServiceClient struct {
+	client *Client
+}
+
+// List the fields of the ticket form, including their choices and nested fields
+func (c *TicketFieldServiceClient) List(ctx context.Context) ([]TicketFieldDetails, error) {
+	list, _, err := c.ListWithResponse(ctx)
+	return list, err
+}
+
+// ListWithResponse is the same as List but also returns the Freshservice API response
+func (c *TicketFieldServiceClient) ListWithResponse(ctx context.Context) ([]TicketFieldDetails, *Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodGet, ticketFieldURL, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := &TicketFields{}
+	resp, err := c.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return res.List, resp, nil
+}
+
+// Validate fetches the ticket form and checks the ticket against it with
+// ValidateTicket. Fetch the fields once with List and call ValidateTicket
+// directly when validating many tickets.
+func (c *TicketFieldServiceClient) Validate(ctx context.Context, td *TicketDetails) ([]Error, error) {
+	fields, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ValidateTicket(fields, td), nil
+}
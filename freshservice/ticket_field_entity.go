@@ -0,0 +1,44 @@
+package freshservice
+
+import "time"
+
+// TicketFields holds the fields of the Freshservice ticket form
+type TicketFields struct {
+	List []TicketFieldDetails `json:"ticket_fields"`
+}
+
+// TicketFieldDetails describes a default or custom field of the ticket form
+type TicketFieldDetails struct {
+	ID                   int                 `json:"id"`
+	WorkspaceID          int                 `json:"workspace_id"`
+	Name                 string              `json:"name"`
+	Label                string              `json:"label"`
+	Description          string              `json:"description"`
+	FieldType            string              `json:"field_type"`
+	Position             int                 `json:"position"`
+	DefaultField         bool                `json:"default_field"`
+	RequiredForAgents    bool                `json:"required_for_agents"`
+	RequiredForCustomers bool                `json:"required_for_customers"`
+	RequiredForClosure   bool                `json:"required_for_closure"`
+	Choices              []TicketFieldChoice `json:"choices"`
+	NestedFields         []NestedTicketField `json:"nested_fields"`
+	CreatedAt            time.Time           `json:"created_at"`
+	UpdatedAt            time.Time           `json:"updated_at"`
+}
+
+// TicketFieldChoice is a choice of a dropdown field. The choices of a
+// nested field list the choices of the next level in NestedOptions.
+type TicketFieldChoice struct {
+	ID            int                 `json:"id"`
+	Value         string              `json:"value"`
+	NestedOptions []TicketFieldChoice `json:"nested_options"`
+}
+
+// NestedTicketField is a dependent level of a nested field, such as the
+// sub category of the category field
+type NestedTicketField struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Level int    `json:"level"`
+}
@@ -0,0 +1,82 @@
+package freshservice_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/CoreyGriffin/go-freshservice/freshservice"
+	"github.com/stretchr/testify/assert"
+)
+
+const ticketFormFields = `{"ticket_fields":[
+	{"id":1,"name":"requester","label":"Requester","field_type":"default_requester","default_field":true,"required_for_agents":true},
+	{"id":2,"name":"subject","label":"Subject","field_type":"default_subject","default_field":true,"required_for_agents":true},
+	{"id":3,"name":"status","label":"Status","field_type":"default_status","default_field":true,"required_for_agents":true,
+		"choices":[{"id":2,"value":"Open"},{"id":3,"value":"Pending"},{"id":4,"value":"Resolved"},{"id":5,"value":"Closed"}]},
+	{"id":4,"name":"ticket_type","label":"Type","field_type":"default_ticket_type","default_field":true,
+		"choices":[{"id":1,"value":"Incident"},{"id":2,"value":"Service Request"}]},
+	{"id":5,"name":"category","label":"Category","field_type":"default_category","default_field":true,
+		"choices":[{"id":1,"value":"Hardware","nested_options":[{"id":2,"value":"Computer","nested_options":[{"id":3,"value":"Mac"}]}]},{"id":4,"value":"Software"}],
+		"nested_fields":[{"id":6,"name":"item_category","level":3},{"id":7,"name":"sub_category","level":2}]},
+	{"id":8,"name":"root_cause","label":"Root cause","field_type":"custom_paragraph","required_for_closure":true},
+	{"id":9,"name":"cf_hours_lost","label":"Hours lost","field_type":"custom_number"},
+	{"id":10,"name":"outage_date","label":"Outage date","field_type":"custom_date"},
+	{"id":11,"name":"site","label":"Site","field_type":"custom_dropdown","choices":[{"id":1,"value":"London"},{"id":2,"value":"Paris"}]}
+]}`
+
+func TestTicketFieldsValidate(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/ticket_form_fields", r.URL.Path)
+		fmt.Fprint(w, ticketFormFields)
+	})
+	defer server.Close()
+
+	fields, err := c.TicketFields().List(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, fields, 9)
+	assert.Equal(t, "Computer", fields[4].Choices[0].NestedOptions[0].Value)
+	assert.Equal(t, "sub_category", fields[4].NestedFields[1].Name)
+
+	valid := &freshservice.TicketDetails{
+		RequesterID:  3,
+		Subject:      "Laptop broken",
+		Status:       freshservice.TicketOpen,
+		Type:         "Incident",
+		Category:     "Hardware",
+		SubCategory:  "Computer",
+		ItemCategory: "Mac",
+		CustomFields: freshservice.CustomFields{"hours_lost": 3, "outage_date": "2021-03-04", "site": "Paris"},
+	}
+	errs, err := c.TicketFields().Validate(context.Background(), valid)
+	assert.Nil(t, err)
+	assert.Nil(t, errs)
+
+	assert.Equal(t, []freshservice.Error{
+		{Field: "requester_id", Message: "It should not be blank as this is a mandatory field", Code: "missing_field"},
+		{Field: "subject", Message: "It should not be blank as this is a mandatory field", Code: "missing_field"},
+		{Field: "status", Message: "It should be one of these values: '2,3,4,5'", Code: "invalid_value"},
+		{Field: "type", Message: "It should be one of these values: 'Incident,Service Request'", Code: "invalid_value"},
+		{Field: "item_category", Message: "It should be blank as sub_category is not set", Code: "invalid_value"},
+		{Field: "hours_lost", Message: "It should be a/an Integer", Code: "datatype_mismatch"},
+		{Field: "outage_date", Message: "It should be in the 'YYYY-MM-DD' format", Code: "invalid_value"},
+		{Field: "site", Message: "It should be one of these values: 'London,Paris'", Code: "invalid_value"},
+		{Field: "sla_tier", Message: "Unexpected/invalid field in request", Code: "invalid_field"},
+	}, freshservice.ValidateTicket(fields, &freshservice.TicketDetails{
+		Status:       9,
+		Type:         "Problem",
+		Category:     "Hardware",
+		ItemCategory: "Mac",
+		CustomFields: freshservice.CustomFields{"hours_lost": 1.5, "outage_date": "yesterday", "site": "Berlin", "sla_tier": "gold"},
+	}))
+
+	// closing a ticket requires the fields required for closure
+	closing := *valid
+	closing.Status = freshservice.TicketResolved
+	closing.SubCategory = "Monitor"
+	assert.Equal(t, []freshservice.Error{
+		{Field: "sub_category", Message: "It should be one of these values: 'Computer'", Code: "invalid_value"},
+		{Field: "root_cause", Message: "It should not be blank as this is a mandatory field", Code: "missing_field"},
+	}, freshservice.ValidateTicket(fields, &closing))
+}
@@ -0,0 +1,274 @@
+package freshservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The error codes Freshservice uses for invalid ticket fields
+const (
+	missingFieldCode     = "missing_field"
+	invalidValueCode     = "invalid_value"
+	datatypeMismatchCode = "datatype_mismatch"
+	invalidFieldCode     = "invalid_field"
+)
+
+// defaultTicketFieldKeys maps the ticket form names of default fields to the
+// JSON fields of a ticket that set them, other default fields share their name
+var defaultTicketFieldKeys = map[string][]string{
+	"requester":   {"requester_id", "email"},
+	"ticket_type": {"type"},
+	"group":       {"group_id"},
+	"responder":   {"responder_id"},
+	"agent":       {"responder_id"},
+	"department":  {"department_id"},
+}
+
+// ValidateTicket checks a ticket against the fields of the ticket form
+// before it is created or updated. It reports missing required fields,
+// including those required to resolve or close the ticket, values that
+// are not one of the choices of a dropdown or nested field, custom field
+// values of the wrong type and unknown custom fields. Errors use the
+// fields and codes Freshservice would reply with, nil means the ticket is valid.
+func ValidateTicket(fields []TicketFieldDetails, td *TicketDetails) []Error {
+	if td == nil {
+		td = &TicketDetails{}
+	}
+
+	b, err := json.Marshal(td)
+	if err != nil {
+		return []Error{{Message: err.Error(), Code: invalidValueCode}}
+	}
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return []Error{{Message: err.Error(), Code: invalidValueCode}}
+	}
+	custom, _ := payload["custom_fields"].(map[string]interface{})
+
+	v := &ticketValidator{
+		payload: payload,
+		custom:  custom,
+		closing: td.Status == TicketResolved || td.Status == TicketClosed,
+		known:   map[string]bool{},
+	}
+	for _, f := range fields {
+		v.check(f)
+	}
+	v.checkUnknown()
+
+	return v.errs
+}
+
+// ticketValidator collects the errors of a ticket encoded as it is sent
+type ticketValidator struct {
+	payload map[string]interface{}
+	custom  map[string]interface{}
+	closing bool
+	known   map[string]bool
+	errs    []Error
+}
+
+func (v *ticketValidator) add(field, code, format string, args ...interface{}) {
+	v.errs = append(v.errs, Error{Field: field, Message: fmt.Sprintf(format, args...), Code: code})
+}
+
+// check validates the value of a single form field and its nested fields
+func (v *ticketValidator) check(f TicketFieldDetails) {
+	key, value := v.lookup(f.Name, f.DefaultField)
+	for _, nf := range f.NestedFields {
+		// nested custom fields are known even when their parent is invalid
+		v.lookup(nf.Name, f.DefaultField)
+	}
+
+	if isBlankField(value, f.DefaultField) {
+		if f.RequiredForAgents || (f.RequiredForClosure && v.closing) {
+			v.add(key, missingFieldCode, "It should not be blank as this is a mandatory field")
+		}
+		v.checkNested(f, key, nil)
+		return
+	}
+
+	if !f.DefaultField && !v.checkType(key, f.FieldType, value) {
+		return
+	}
+
+	if len(f.Choices) == 0 {
+		return
+	}
+	choice := findChoice(f.Choices, value)
+	if choice == nil {
+		v.add(key, invalidValueCode, "It should be one of these values: '%s'", choiceList(f.Choices, value))
+		return
+	}
+	v.checkNested(f, key, choice)
+}
+
+// checkNested validates the dependent levels of a nested field, each
+// level must be one of the nested options of the level above it
+func (v *ticketValidator) checkNested(f TicketFieldDetails, parentKey string, parent *TicketFieldChoice) {
+	nested := append([]NestedTicketField(nil), f.NestedFields...)
+	sort.Slice(nested, func(i, j int) bool { return nested[i].Level < nested[j].Level })
+
+	for _, nf := range nested {
+		key, value := v.lookup(nf.Name, f.DefaultField)
+		if isBlankField(value, f.DefaultField) {
+			parent, parentKey = nil, key
+			continue
+		}
+
+		if parent == nil {
+			v.add(key, invalidValueCode, "It should be blank as %s is not set", parentKey)
+			return
+		}
+		if len(parent.NestedOptions) == 0 {
+			parent, parentKey = nil, key
+			continue
+		}
+
+		choice := findChoice(parent.NestedOptions, value)
+		if choice == nil {
+			v.add(key, invalidValueCode, "It should be one of these values: '%s'", choiceList(parent.NestedOptions, value))
+			return
+		}
+		parent, parentKey = choice, key
+	}
+}
+
+// checkType reports a custom field value that does not match the field type
+func (v *ticketValidator) checkType(key string, fieldType string, value interface{}) bool {
+	var ok bool
+	var want string
+
+	switch fieldType {
+	case "custom_text", "custom_paragraph", "custom_dropdown", "nested_field", "custom_url", "custom_phone_number":
+		_, ok = value.(string)
+		want = "String"
+	case "custom_number":
+		n, isNumber := value.(float64)
+		ok = isNumber && n == float64(int64(n))
+		want = "Integer"
+	case "custom_decimal":
+		_, ok = value.(float64)
+		want = "Number"
+	case "custom_checkbox":
+		_, ok = value.(bool)
+		want = "Boolean"
+	case "custom_date":
+		s, isString := value.(string)
+		if isString && !isDate(s) {
+			v.add(key, invalidValueCode, "It should be in the 'YYYY-MM-DD' format")
+			return false
+		}
+		ok = isString
+		want = "String"
+	default:
+		return true
+	}
+
+	if !ok {
+		v.add(key, datatypeMismatchCode, "It should be a/an %s", want)
+	}
+	return ok
+}
+
+// checkUnknown reports custom fields that are not on the ticket form
+func (v *ticketValidator) checkUnknown() {
+	var unknown []string
+	for name := range v.custom {
+		if !v.known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+
+	for _, name := range unknown {
+		v.add(name, invalidFieldCode, "Unexpected/invalid field in request")
+	}
+}
+
+// lookup returns the key and value of a form field in the ticket. Custom
+// fields may be named with or without the cf_ prefix of the ticket form.
+func (v *ticketValidator) lookup(name string, defaultField bool) (string, interface{}) {
+	if defaultField {
+		keys, ok := defaultTicketFieldKeys[name]
+		if !ok {
+			keys = []string{name}
+		}
+		for _, key := range keys {
+			if value := v.payload[key]; !isBlankField(value, true) {
+				return key, value
+			}
+		}
+		return keys[0], nil
+	}
+
+	key := name
+	if _, ok := v.custom[key]; !ok {
+		if trimmed := strings.TrimPrefix(name, "cf_"); trimmed != name {
+			if _, ok := v.custom[trimmed]; ok {
+				key = trimmed
+			}
+		}
+	}
+	v.known[key] = true
+	return key, v.custom[key]
+}
+
+// isBlankField reports whether a field is unset. The zero values of the
+// numbers of default ticket fields are always sent, so they count as unset.
+func isBlankField(value interface{}, defaultField bool) bool {
+	switch val := value.(type) {
+	case nil:
+		return true
+	case string:
+		return val == ""
+	case float64:
+		return defaultField && val == 0
+	case []interface{}:
+		return len(val) == 0
+	}
+	return false
+}
+
+// findChoice returns the choice matching a value, numbers are matched
+// with the choice IDs and text with the choice values
+func findChoice(choices []TicketFieldChoice, value interface{}) *TicketFieldChoice {
+	for i, c := range choices {
+		switch val := value.(type) {
+		case float64:
+			if float64(c.ID) == val {
+				return &choices[i]
+			}
+		case string:
+			if c.Value == val {
+				return &choices[i]
+			}
+		}
+	}
+	return nil
+}
+
+// choiceList lists the choices in the form matched by the value
+func choiceList(choices []TicketFieldChoice, value interface{}) string {
+	list := make([]string, len(choices))
+	for i, c := range choices {
+		if _, ok := value.(float64); ok {
+			list[i] = strconv.Itoa(c.ID)
+		} else {
+			list[i] = c.Value
+		}
+	}
+	return strings.Join(list, ",")
+}
+
+func isDate(s string) bool {
+	if _, err := time.Parse("2006-01-02", s); err == nil {
+		return true
+	}
+	_, err := time.Parse(time.RFC3339, s)
+	return err == nil
+}
@@ -0,0 +1,163 @@
+package freshservice_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/CoreyGriffin/go-freshservice/freshservice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTicketGetEmbeds(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/tickets/8", r.URL.Path)
+		assert.Equal(t, "requester,stats,tags,department", r.URL.Query().Get("include"))
+		fmt.Fprint(w, `{"ticket":{"id":8,"tags":["vpn"],
+			"requester":{"id":3,"name":"Ada","email":"ada@example.com","mobile":null},
+			"department":{"id":5,"name":"IT"},
+			"stats":{"ticket_id":8,"first_responded_at":"2021-03-04T05:06:07Z","resolved_at":null,"first_resp_time_in_secs":60}}}`)
+	})
+	defer server.Close()
+
+	ticket, err := c.Tickets().Get(context.Background(), 8, &freshservice.TicketGetOptions{
+		Embed: &freshservice.TicketGetEmbedOptions{RequesterInfo: true, Stats: true, Tags: true, Department: true},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"vpn"}, ticket.Tags)
+	assert.Equal(t, "ada@example.com", ticket.Requester.Email)
+	assert.Equal(t, "IT", ticket.Department.Name)
+	assert.Equal(t, time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC), *ticket.Stats.FirstRespondedAt)
+	assert.Nil(t, ticket.Stats.ResolvedAt)
+	assert.Equal(t, 60, ticket.Stats.FirstRespTimeInSecs)
+
+	// embedded details are not sent back when updating the ticket
+	b, err := json.Marshal(ticket)
+	assert.Nil(t, err)
+	var body map[string]interface{}
+	assert.Nil(t, json.Unmarshal(b, &body))
+	assert.Equal(t, []interface{}{"vpn"}, body["tags"])
+	for _, field := range []string{"stats", "requester", "department", "conversations", "assets"} {
+		assert.NotContains(t, body, field)
+	}
+}
+
+func TestTicketListEmbeds(t *testing.T) {
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "requester,stats", r.URL.Query().Get("include"))
+		fmt.Fprint(w, `{"tickets":[{"id":1,"requester":{"id":3,"name":"Ada"},"stats":{"closed_at":"2021-03-05T00:00:00Z"}}]}`)
+	})
+	defer server.Close()
+
+	tickets, _, err := c.Tickets().List(context.Background(), &freshservice.TicketListOptions{
+		Embed: &freshservice.TicketEmbedOptions{RequesterInfo: true, Stats: true},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Ada", tickets[0].Requester.Name)
+	assert.Equal(t, 2021, tickets[0].Stats.ClosedAt.Year())
+}
+
+func TestTicketTrashAndSpam(t *testing.T) {
+	var requests []string
+	var body map[string]interface{}
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodPut:
+			if r.URL.Path == "/api/v2/tickets/5/restore" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			body = nil
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+			fmt.Fprint(w, `{"ticket":{"id":5,"spam":true}}`)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	defer server.Close()
+
+	ctx := context.Background()
+	assert.Nil(t, c.Tickets().Delete(ctx, 5))
+	assert.Nil(t, c.Tickets().Restore(ctx, 5))
+
+	ticket, err := c.Tickets().SetSpam(ctx, 5, true)
+	assert.Nil(t, err)
+	assert.True(t, ticket.Spam)
+	assert.Equal(t, map[string]interface{}{"spam": true}, body)
+
+	_, err = c.Tickets().SetSpam(ctx, 5, false)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"spam": false}, body)
+
+	assert.Nil(t, c.Tickets().DeletePermanently(ctx, 5))
+
+	assert.Equal(t, []string{
+		"DELETE /api/v2/tickets/5",
+		"PUT /api/v2/tickets/5/restore",
+		"PUT /api/v2/tickets/5",
+		"PUT /api/v2/tickets/5",
+		"DELETE /api/v2/tickets/5/delete_forever",
+	}, requests)
+}
+
+func TestTicketListDeletedAndSpam(t *testing.T) {
+	var queries []string
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		fmt.Fprint(w, `{"tickets":[{"id":1,"deleted":true}]}`)
+	})
+	defer server.Close()
+
+	opts := &freshservice.TicketListOptions{
+		FilterBy: &freshservice.TicketFilter{Watching: true, RequesterID: freshservice.Int(3)},
+		SortBy:   &freshservice.SortOptions{Ascending: true},
+	}
+
+	deleted, err := c.Tickets().ListAllDeleted(context.Background(), opts)
+	assert.Nil(t, err)
+	assert.True(t, deleted[0].Deleted)
+
+	it := c.Tickets().IterSpam(context.Background(), nil)
+	for it.Next() {
+	}
+	assert.Nil(t, it.Err())
+
+	assert.Equal(t, []string{"filter=deleted&order_type=asc&requester_id=3", "filter=spam"}, queries)
+	// the options passed in are left unchanged
+	assert.True(t, opts.FilterBy.Watching)
+	assert.False(t, opts.FilterBy.Deleted)
+}
+
+func TestTicketRequestedItems(t *testing.T) {
+	var body map[string]interface{}
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "/api/v2/tickets/77/requested_items", r.URL.Path)
+			fmt.Fprint(w, `{"requested_items":[{"id":3,"service_item_id":21,"quantity":2,"stage":1,"cost_per_request":999.5,"custom_fields":{"operating_system":"Linux"}}]}`)
+		case http.MethodPut:
+			assert.Equal(t, "/api/v2/tickets/77/requested_items/3", r.URL.Path)
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+			fmt.Fprint(w, `{"requested_item":{"id":3,"stage":2}}`)
+		}
+	})
+	defer server.Close()
+
+	items, err := c.Tickets().RequestedItems(context.Background(), 77)
+	assert.Nil(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, freshservice.RequestedItemRequested, items[0].Stage)
+	assert.Equal(t, 999.5, items[0].CostPerRequest)
+	assert.Equal(t, "Linux", items[0].CustomFields["operating_system"])
+
+	item, err := c.Tickets().UpdateRequestedItem(context.Background(), 77, 3, &freshservice.RequestedItemUpdate{
+		Stage: freshservice.Int(freshservice.RequestedItemDelivered),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, freshservice.RequestedItemDelivered, item.Stage)
+	assert.Equal(t, map[string]interface{}{"stage": float64(2)}, body)
+}
@@ -0,0 +1,289 @@
+package freshservice
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+/*
+NOTE: The time entry methods are related to the ticket methods and use the ticket endpoint
+*/
+
+// TimeEntryService is an interface for interacting with
+// the ticket time entry endpoints of the Freshservice API
+type TimeEntryService interface {
+	List(context.Context, int) ([]TimeEntryDetails, error)
+	ListWithResponse(context.Context, int) ([]TimeEntryDetails, *Response, error)
+	ListAll(context.Context, int) ([]TimeEntryDetails, error)
+	Iter(context.Context, int) *TimeEntryIterator
+	Get(context.Context, int, int) (*TimeEntryDetails, error)
+	GetWithResponse(context.Context, int, int) (*TimeEntryDetails, *Response, error)
+	Create(context.Context, int, *TimeEntryDetails) (*TimeEntryDetails, error)
+	CreateWithResponse(context.Context, int, *TimeEntryDetails) (*TimeEntryDetails, *Response, error)
+	Update(context.Context, int, int, *TimeEntryUpdate) (*TimeEntryDetails, error)
+	UpdateWithResponse(context.Context, int, int, *TimeEntryUpdate) (*TimeEntryDetails, *Response, error)
+	Delete(context.Context, int, int) error
+	DeleteWithResponse(context.Context, int, int) (*Response, error)
+	StartTimer(int, *TimeEntryDetails) *TimeEntryTimer
+}
+
+// TimeEntryServiceClient facilitates requests with the TimeEntryService methods
+type TimeEntryServiceClient struct {
+	client *Client
+}
+
+// List the time entries logged on a given ticket ID
+func (c *TimeEntryServiceClient) List(ctx context.Context, tickID int) ([]TimeEntryDetails, error) {
+	list, _, err := c.ListWithResponse(ctx, tickID)
+	return list, err
+}
+
+// ListWithResponse is the same as List but also returns the Freshservice API response
+func (c *TimeEntryServiceClient) ListWithResponse(ctx context.Context, tickID int) ([]TimeEntryDetails, *Response, error) {
+	return c.list(ctx, tickID, nil)
+}
+
+// list requests a page of time entries for a given ticket ID
+func (c *TimeEntryServiceClient) list(ctx context.Context, tickID int, filter QueryFilter) ([]TimeEntryDetails, *Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%d/time_entries", ticketURL, tickID), filter, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := &TimeEntries{}
+	resp, err := c.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return res.List, resp, nil
+}
+
+// Iter returns an iterator over every time entry logged on a given ticket ID,
+// following the pagination links returned by Freshservice
+func (c *TimeEntryServiceClient) Iter(ctx context.Context, tickID int) *TimeEntryIterator {
+	it := &TimeEntryIterator{}
+	it.pager = newPager(ctx, nil, func(ctx context.Context, f QueryFilter) (*Response, error) {
+		list, resp, err := c.list(ctx, tickID, f)
+		it.page = list
+		return resp, err
+	})
+	return it
+}
+
+// ListAll returns every time entry logged on a given ticket ID across all pages
+func (c *TimeEntryServiceClient) ListAll(ctx context.Context, tickID int) ([]TimeEntryDetails, error) {
+	var all []TimeEntryDetails
+	it := c.Iter(ctx, tickID)
+	for it.Next() {
+		all = append(all, it.Value())
+	}
+	return all, it.Err()
+}
+
+// TimeEntryIterator iterates over Freshservice time entries page by page
+type TimeEntryIterator struct {
+	pager
+	page []TimeEntryDetails
+	cur  TimeEntryDetails
+}
+
+// Next advances the iterator to the next time entry. It returns
+// false when there are none left or an error occurred.
+func (it *TimeEntryIterator) Next() bool {
+	for len(it.page) == 0 {
+		if !it.fetchNext() {
+			return false
+		}
+	}
+
+	if !it.take() {
+		return false
+	}
+
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current time entry
+func (it *TimeEntryIterator) Value() TimeEntryDetails {
+	return it.cur
+}
+
+// Get a specific time entry logged on a given ticket ID
+func (c *TimeEntryServiceClient) Get(ctx context.Context, tickID int, id int) (*TimeEntryDetails, error) {
+	details, _, err := c.GetWithResponse(ctx, tickID, id)
+	return details, err
+}
+
+// GetWithResponse is the same as Get but also returns the Freshservice API response
+func (c *TimeEntryServiceClient) GetWithResponse(ctx context.Context, tickID int, id int) (*TimeEntryDetails, *Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%d/time_entries/%d", ticketURL, tickID, id), nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.send(req)
+}
+
+// Create a time entry on a given ticket ID
+func (c *TimeEntryServiceClient) Create(ctx context.Context, tickID int, te *TimeEntryDetails) (*TimeEntryDetails, error) {
+	details, _, err := c.CreateWithResponse(ctx, tickID, te)
+	return details, err
+}
+
+// CreateWithResponse is the same as Create but also returns the Freshservice API response
+func (c *TimeEntryServiceClient) CreateWithResponse(ctx context.Context, tickID int, te *TimeEntryDetails) (*TimeEntryDetails, *Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%d/time_entries", ticketURL, tickID), nil, te)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.send(req)
+}
+
+// Update a specific time entry logged on a given ticket ID, sending only
+// the fields set on the update
+func (c *TimeEntryServiceClient) Update(ctx context.Context, tickID int, id int, u *TimeEntryUpdate) (*TimeEntryDetails, error) {
+	details, _, err := c.UpdateWithResponse(ctx, tickID, id, u)
+	return details, err
+}
+
+// UpdateWithResponse is the same as Update but also returns the Freshservice API response
+func (c *TimeEntryServiceClient) UpdateWithResponse(ctx context.Context, tickID int, id int, u *TimeEntryUpdate) (*TimeEntryDetails, *Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%d/time_entries/%d", ticketURL, tickID, id), nil, u)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.send(req)
+}
+
+// Delete a specific time entry logged on a given ticket ID
+func (c *TimeEntryServiceClient) Delete(ctx context.Context, tickID int, id int) error {
+	_, err := c.DeleteWithResponse(ctx, tickID, id)
+	return err
+}
+
+// DeleteWithResponse is the same as Delete but also returns the Freshservice API response
+func (c *TimeEntryServiceClient) DeleteWithResponse(ctx context.Context, tickID int, id int) (*Response, error) {
+	req, err := c.client.newRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%d/time_entries/%d", ticketURL, tickID, id), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.makeRequest(req, nil)
+}
+
+// send makes a request returning a single time entry
+func (c *TimeEntryServiceClient) send(req *http.Request) (*TimeEntryDetails, *Response, error) {
+	res := &TimeEntry{}
+	resp, err := c.client.makeRequest(req, res)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &res.Details, resp, nil
+}
+
+// StartTimer starts timing work on a given ticket ID locally. Nothing is
+// sent to Freshservice until the timer is stopped, when the time spent is
+// logged as a new time entry based on the given details.
+func (c *TimeEntryServiceClient) StartTimer(tickID int, te *TimeEntryDetails) *TimeEntryTimer {
+	t := &TimeEntryTimer{service: c, tickID: tickID}
+	if te != nil {
+		t.entry = *te
+	}
+	t.started = time.Now()
+	t.first = t.started
+	t.running = true
+	return t
+}
+
+// TimeEntryTimer times work on a ticket and logs it as a time entry when
+// stopped. It is safe for concurrent use.
+type TimeEntryTimer struct {
+	mu      sync.Mutex
+	service TimeEntryService
+	tickID  int
+	entry   TimeEntryDetails
+	first   time.Time
+	started time.Time
+	elapsed time.Duration
+	running bool
+	posted  bool
+}
+
+// Pause stops the clock without logging the time spent
+func (t *TimeEntryTimer) Pause() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.running {
+		t.elapsed += time.Since(t.started)
+		t.running = false
+	}
+}
+
+// Resume restarts the clock of a paused timer
+func (t *TimeEntryTimer) Resume() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.running && !t.posted {
+		t.started = time.Now()
+		t.running = true
+	}
+}
+
+// Elapsed returns the time spent so far, excluding any pauses
+func (t *TimeEntryTimer) Elapsed() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.running {
+		return t.elapsed + time.Since(t.started)
+	}
+	return t.elapsed
+}
+
+// Stop stops the clock and creates a time entry for the time spent, started
+// and executed when the timer was started unless the details set them.
+// A failed Stop can be retried, stopping a timer that was already logged
+// or is being logged returns ErrTimerPosted.
+func (t *TimeEntryTimer) Stop(ctx context.Context) (*TimeEntryDetails, error) {
+	t.mu.Lock()
+	if t.posted {
+		t.mu.Unlock()
+		return nil, ErrTimerPosted
+	}
+	if t.running {
+		t.elapsed += time.Since(t.started)
+		t.running = false
+	}
+
+	te := t.entry
+	te.TimeSpent = Duration(t.elapsed)
+	te.TimerRunning = false
+	if te.StartTime.IsZero() {
+		te.StartTime = t.first
+	}
+	if te.ExecutedAt.IsZero() {
+		te.ExecutedAt = t.first
+	}
+	// the lock is not held while posting so the timer can still be read
+	t.posted = true
+	t.mu.Unlock()
+
+	details, err := t.service.Create(ctx, t.tickID, &te)
+	if err != nil {
+		t.mu.Lock()
+		t.posted = false
+		t.mu.Unlock()
+		return nil, err
+	}
+	return details, nil
+}
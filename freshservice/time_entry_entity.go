@@ -0,0 +1,146 @@
+package freshservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeEntries holds a list of Freshservice time entries
+type TimeEntries struct {
+	List []TimeEntryDetails `json:"time_entries"`
+}
+
+// TimeEntry holds the details of a specific Freshservice time entry
+type TimeEntry struct {
+	Details TimeEntryDetails `json:"time_entry"`
+}
+
+// TimeEntryDetails are the details of time logged on a ticket
+type TimeEntryDetails struct {
+	ID           int          `json:"id"`
+	AgentID      int          `json:"agent_id"`
+	TaskID       int          `json:"task_id"`
+	Billable     bool         `json:"billable"`
+	Note         string       `json:"note"`
+	TimeSpent    Duration     `json:"time_spent"`
+	TimerRunning bool         `json:"timer_running"`
+	StartTime    time.Time    `json:"start_time"`
+	ExecutedAt   time.Time    `json:"executed_at"`
+	CustomFields CustomFields `json:"custom_fields"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// MarshalJSON only encodes the fields that can be set when creating a
+// time entry, leaving out unset IDs and timestamps
+func (te TimeEntryDetails) MarshalJSON() ([]byte, error) {
+	type entry struct {
+		AgentID      int          `json:"agent_id,omitempty"`
+		TaskID       int          `json:"task_id,omitempty"`
+		Billable     bool         `json:"billable"`
+		Note         string       `json:"note,omitempty"`
+		TimeSpent    Duration     `json:"time_spent"`
+		TimerRunning bool         `json:"timer_running"`
+		StartTime    *time.Time   `json:"start_time,omitempty"`
+		ExecutedAt   *time.Time   `json:"executed_at,omitempty"`
+		CustomFields CustomFields `json:"custom_fields,omitempty"`
+	}
+
+	e := entry{
+		AgentID:      te.AgentID,
+		TaskID:       te.TaskID,
+		Billable:     te.Billable,
+		Note:         te.Note,
+		TimeSpent:    te.TimeSpent,
+		TimerRunning: te.TimerRunning,
+		CustomFields: te.CustomFields,
+	}
+	if !te.StartTime.IsZero() {
+		e.StartTime = &te.StartTime
+	}
+	if !te.ExecutedAt.IsZero() {
+		e.ExecutedAt = &te.ExecutedAt
+	}
+	return json.Marshal(e)
+}
+
+// TimeEntryUpdate holds the changes made to a time entry with Update.
+// Only the fields that are set are sent, list the JSON names of fields
+// to clear, such as "task_id", in Null.
+type TimeEntryUpdate struct {
+	AgentID      *int         `json:"agent_id,omitempty"`
+	TaskID       *int         `json:"task_id,omitempty"`
+	Billable     *bool        `json:"billable,omitempty"`
+	Note         *string      `json:"note,omitempty"`
+	TimeSpent    *Duration    `json:"time_spent,omitempty"`
+	TimerRunning *bool        `json:"timer_running,omitempty"`
+	StartTime    *time.Time   `json:"start_time,omitempty"`
+	ExecutedAt   *time.Time   `json:"executed_at,omitempty"`
+	CustomFields CustomFields `json:"custom_fields,omitempty"`
+	Null         []string     `json:"-"`
+}
+
+// MarshalJSON encodes the fields that are set and those cleared with Null
+func (u *TimeEntryUpdate) MarshalJSON() ([]byte, error) {
+	type update TimeEntryUpdate
+	return marshalUpdate((*update)(u), u.Null)
+}
+
+// Duration is a length of time encoded in the "hh:mm" format Freshservice
+// uses for time spent. It is rounded to the nearest minute when encoded.
+type Duration time.Duration
+
+// String formats the duration as hours and minutes
+func (d Duration) String() string {
+	minutes := time.Duration(d).Round(time.Minute) / time.Minute
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// MarshalJSON encodes the duration as a "hh:mm" string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	if d < 0 {
+		return nil, fmt.Errorf("negative time spent %v", time.Duration(d))
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a "hh:mm" string, a null time spent is zero
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s *string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s == nil {
+		*d = 0
+		return nil
+	}
+
+	parsed, err := parseTimeSpent(*s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// parseTimeSpent parses a "hh:mm" time spent, hours may exceed 24
+func parseTimeSpent(s string) (time.Duration, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("time spent %q is not in the hh:mm format", s)
+	}
+
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil || hours < 0 {
+		return 0, fmt.Errorf("time spent %q has invalid hours", s)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil || minutes < 0 || minutes > 59 {
+		return 0, fmt.Errorf("time spent %q has invalid minutes", s)
+	}
+
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, nil
+}
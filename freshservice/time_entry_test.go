@@ -0,0 +1,162 @@
+package freshservice_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/CoreyGriffin/go-freshservice/freshservice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeEntryCRUD(t *testing.T) {
+	var requests []string
+	var body map[string]interface{}
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"time_entries":[{"id":1,"agent_id":3,"billable":true,"time_spent":"26:05","start_time":"2021-03-04T05:06:07Z"},{"id":2,"time_spent":null}]}`)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			body = nil
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+			fmt.Fprint(w, `{"time_entry":{"id":4,"time_spent":"01:30"}}`)
+		}
+	})
+	defer server.Close()
+
+	ctx := context.Background()
+	entries, err := c.TimeEntries().List(ctx, 7)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, 26*time.Hour+5*time.Minute, time.Duration(entries[0].TimeSpent))
+	assert.True(t, entries[0].Billable)
+	assert.Equal(t, freshservice.Duration(0), entries[1].TimeSpent)
+
+	entry, err := c.TimeEntries().Create(ctx, 7, &freshservice.TimeEntryDetails{
+		AgentID:   3,
+		Billable:  true,
+		Note:      "Replaced disk",
+		TimeSpent: freshservice.Duration(89*time.Minute + 40*time.Second),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Minute, time.Duration(entry.TimeSpent))
+	assert.Equal(t, map[string]interface{}{
+		"agent_id":      float64(3),
+		"billable":      true,
+		"note":          "Replaced disk",
+		"time_spent":    "01:30",
+		"timer_running": false,
+	}, body)
+
+	hour := freshservice.Duration(time.Hour)
+	_, err = c.TimeEntries().Update(ctx, 7, 4, &freshservice.TimeEntryUpdate{TimeSpent: &hour, TaskID: freshservice.Int(2)})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"time_spent": "01:00", "task_id": float64(2)}, body)
+
+	// the time spent and billable flag are left alone when only the note changes
+	_, err = c.TimeEntries().Update(ctx, 7, 4, &freshservice.TimeEntryUpdate{Note: freshservice.String("x")})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"note": "x"}, body)
+
+	assert.Nil(t, c.TimeEntries().Delete(ctx, 7, 4))
+
+	assert.Equal(t, []string{
+		"GET /api/v2/tickets/7/time_entries",
+		"POST /api/v2/tickets/7/time_entries",
+		"PUT /api/v2/tickets/7/time_entries/4",
+		"PUT /api/v2/tickets/7/time_entries/4",
+		"DELETE /api/v2/tickets/7/time_entries/4",
+	}, requests)
+}
+
+func TestDurationUnmarshalInvalid(t *testing.T) {
+	for _, s := range []string{`"90"`, `"1:60"`, `"-1:00"`, `"a:00"`, `90`} {
+		var d freshservice.Duration
+		assert.NotNil(t, json.Unmarshal([]byte(s), &d), s)
+	}
+}
+
+func TestTimeEntryTimer(t *testing.T) {
+	attempts := 0
+	var body map[string]interface{}
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/tickets/7/time_entries", r.URL.Path)
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		fmt.Fprint(w, `{"time_entry":{"id":9}}`)
+	})
+	defer server.Close()
+
+	before := time.Now()
+	timer := c.TimeEntries().StartTimer(7, &freshservice.TimeEntryDetails{AgentID: 3, Note: "On call"})
+	time.Sleep(5 * time.Millisecond)
+	timer.Pause()
+	paused := timer.Elapsed()
+	assert.True(t, paused >= 5*time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, paused, timer.Elapsed())
+	timer.Resume()
+
+	_, err := timer.Stop(context.Background())
+	assert.NotNil(t, err)
+
+	entry, err := timer.Stop(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 9, entry.ID)
+	assert.Equal(t, "00:00", body["time_spent"])
+	assert.Equal(t, "On call", body["note"])
+	assert.Equal(t, false, body["timer_running"])
+
+	started, err := time.Parse(time.RFC3339, body["start_time"].(string))
+	assert.Nil(t, err)
+	assert.WithinDuration(t, before, started, time.Second)
+	assert.Equal(t, body["start_time"], body["executed_at"])
+
+	_, err = timer.Stop(context.Background())
+	assert.True(t, errors.Is(err, freshservice.ErrTimerPosted))
+	assert.Equal(t, 2, attempts)
+}
+
+func TestTimeEntryTimerStopUnlocked(t *testing.T) {
+	posting := make(chan struct{})
+	release := make(chan struct{})
+	server, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		close(posting)
+		<-release
+		fmt.Fprint(w, `{"time_entry":{"id":9}}`)
+	})
+	defer server.Close()
+
+	timer := c.TimeEntries().StartTimer(7, &freshservice.TimeEntryDetails{AgentID: 3})
+	done := make(chan error)
+	go func() {
+		_, err := timer.Stop(context.Background())
+		done <- err
+	}()
+	<-posting
+
+	// the timer can be read and stopped again while the entry is posted
+	read := make(chan time.Duration)
+	go func() { read <- timer.Elapsed() }()
+	select {
+	case <-read:
+	case <-time.After(time.Second):
+		t.Fatal("Elapsed blocked while the time entry was posted")
+	}
+	_, err := timer.Stop(context.Background())
+	assert.True(t, errors.Is(err, freshservice.ErrTimerPosted))
+
+	close(release)
+	assert.Nil(t, <-done)
+}
@@ -3,7 +3,7 @@ package freshservice
 import (
 	"net/http"
 	"net/url"
-	"strings"
+	"time"
 )
 
 // Int is a built in utility function that will return a *int
@@ -16,6 +16,16 @@ func String(s string) *string {
 	return &s
 }
 
+// Bool is a built in utility function that will return a *bool
+func Bool(b bool) *bool {
+	return &b
+}
+
+// Time is a built in utility function that will return a *time.Time
+func Time(t time.Time) *time.Time {
+	return &t
+}
+
 // StringInSlice is a utility function that can be used to see if a string
 // exists in a static list of strings
 func StringInSlice(a string, list []string) bool {
@@ -29,20 +39,23 @@ func StringInSlice(a string, list []string) bool {
 
 // HasNextPage will take in an http response and check
 // for the existence of the "link" header to determine whether or
-// not there is another page returning the next page's URL
+// not there is another page returning the next page's query string
 // <https://example.freshservice.com/api/v2/tickets?page=2>; rel="next"
+//
+// Deprecated: use the Next cursor of the Response returned by the
+// ...WithResponse methods, or an iterator, instead.
 func HasNextPage(resp *http.Response) string {
-	link, ok := resp.Header["Link"]
-	if !ok {
+	if resp == nil {
 		return ""
 	}
 
-	// pull out raw url
-	u := strings.Split(link[0], ";")[0]
-	// drop the carrots < >
-	u = u[1 : len(u)-1]
+	for _, link := range ParseLinkHeader(resp.Header["Link"]...) {
+		if link.HasRel("next") {
+			return ParseNextPage(link.URL)
+		}
+	}
 
-	return ParseNextPage(u)
+	return ""
 }
 
 // ParseNextPage will return the next page parameter parsed